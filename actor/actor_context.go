@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Define the actorState constants
@@ -26,11 +27,14 @@ type ActorContext struct {
 	children    map[PID]bool
 	self        PID
 	mu          sync.RWMutex
-	actorChan   chan Envelope
+	mailbox     *Mailbox
+	restart     *Restarting // Set when context is created by restart
+	timers      *actorTimers
+	stash       *stash
 }
 
 // NewActorContext creates and initializes a new actorContext
-func NewActorContext(actor Actor, ctx context.Context, actorSystem *ActorSystem, props *ActorProps, self PID, actorChan chan Envelope) *ActorContext {
+func NewActorContext(actor Actor, ctx context.Context, actorSystem *ActorSystem, props *ActorProps, self PID, mailbox *Mailbox) *ActorContext {
 	context := new(ActorContext)
 	context.actor = actor
 	context.ctx = ctx
@@ -39,7 +43,9 @@ func NewActorContext(actor Actor, ctx context.Context, actorSystem *ActorSystem,
 	context.actorSystem = actorSystem
 	context.self = self
 	context.children = make(map[PID]bool) // Initialize children as a map
-	context.actorChan = actorChan
+	context.mailbox = mailbox
+	context.timers = newActorTimers(actorSystem, self)
+	context.stash = &stash{capacity: props.StashCapacity()}
 	return context
 }
 
@@ -70,10 +76,74 @@ func (ctx *ActorContext) SpawnActor(actor Actor, Props ...ActorProps) (PID, erro
 	return pid, nil
 }
 
-// Send message
-func (ctx *ActorContext) Send(message interface{}, receiver PID) {
-	sendEnvelope := NewEnvelope(message, receiver)
-	ctx.actorSystem.Send(sendEnvelope)
+// Spawns child actor with name, its path is path of this actor followed by name
+func (ctx *ActorContext) SpawnNamed(name string, actor Actor, Props ...ActorProps) (PID, error) {
+	prop := ConfigureActorProps(Props...)
+	prop.AddParent(&ctx.self)
+
+	pid, err := ctx.actorSystem.SpawnNamed(name, actor, *prop)
+	if err != nil {
+		return PID{}, err
+	}
+
+	ctx.mu.Lock()
+	ctx.children[pid] = true
+	ctx.mu.Unlock()
+
+	return pid, nil
+}
+
+// Send message, self is set as the sender
+func (ctx *ActorContext) Send(message interface{}, receiver PID) error {
+	sendEnvelope := NewEnvelopeWithSender(message, receiver, ctx.self)
+	return ctx.actorSystem.Send(sendEnvelope)
+}
+
+// SendAfter sends message once delay passes, it is cancelled if actor stops or restarts before that
+func (ctx *ActorContext) SendAfter(delay time.Duration, message interface{}, receiver PID) CancelFunc {
+	return ctx.timers.sendAfter(delay, message, receiver)
+}
+
+// SendRepeatedly sends message after initial delay and then on every interval until cancelled, actor stops or restarts
+func (ctx *ActorContext) SendRepeatedly(initialDelay time.Duration, interval time.Duration, message interface{}, receiver PID) CancelFunc {
+	return ctx.timers.sendRepeatedly(initialDelay, interval, message, receiver)
+}
+
+// SetReceiveTimeout makes actor receive ReceiveTimeout message when no message is received for duration,
+// timeout of zero disables it
+func (ctx *ActorContext) SetReceiveTimeout(timeout time.Duration) {
+	ctx.timers.setReceiveTimeout(timeout)
+}
+
+// Watch makes actor receive Terminated message when watched actor stops,
+// ErrRemoteWatchUnsupported is returned for actors on other nodes
+func (ctx *ActorContext) Watch(pid PID) error {
+	return ctx.actorSystem.watch(ctx.self, pid)
+}
+
+// Unwatch stops watching actor, Terminated message will not be received
+func (ctx *ActorContext) Unwatch(pid PID) {
+	ctx.actorSystem.unwatch(ctx.self, pid)
+}
+
+// Sender returns sender of the current message, nil if message has no sender
+func (ctx *ActorContext) Sender() *PID {
+	return ctx.envelope.Sender()
+}
+
+// Respond sends message to the sender of the current message
+func (ctx *ActorContext) Respond(message interface{}) {
+	sender := ctx.Sender()
+	if sender == nil {
+		ctx.actorSystem.SendToDeadLetters(NewEnvelope(message, PID{}), DeadLetterNoSender)
+		return
+	}
+	ctx.Send(message, *sender)
+}
+
+// Request sends message and returns future that completes with the reply
+func (ctx *ActorContext) Request(message interface{}, receiver PID, timeout time.Duration) *Future {
+	return ctx.actorSystem.Ask(receiver, message, timeout)
 }
 
 func (ctx *ActorContext) Message() interface{} {
@@ -129,13 +199,49 @@ func (ctx *ActorContext) Props() *ActorProps {
 	return ctx.props
 }
 
+// InvokeSystemMessage is called by mailbox, system messages are handled by context
+func (ctx *ActorContext) InvokeSystemMessage(envelope Envelope) {
+	if ctx.state == actorStop {
+		return
+	}
+	if msg, ok := envelope.Message.(SystemMessage); ok {
+		ctx.HandleSystemMessage(msg)
+	}
+}
+
+// InvokeUserMessage is called by mailbox, user message is passed to actor Receive
+func (ctx *ActorContext) InvokeUserMessage(envelope Envelope) {
+	if ctx.state == actorStop {
+		ctx.actorSystem.deadLetters.publishEnvelope(envelope, DeadLetterActorStopped)
+		return
+	}
+	ctx.timers.messageReceived()
+	ctx.AddEnvelope(envelope)
+	ctx.actor.Receive(*ctx)
+}
+
+// HandlePanic is called by mailbox when actor panics, mailbox is suspended and failure is sent to supervisor
+func (ctx *ActorContext) HandlePanic(reason interface{}) {
+	fmt.Println("Actor recovered, need error handling:", reason)
+	ctx.actorSystem.eventStream.Publish(ActorFailed{Who: ctx.self, Reason: reason})
+	ctx.mailbox.suspend()
+	ctx.SuspendChildren()
+
+	failure := Failure{Reason: reason, Who: ctx.self, Actor: ctx.actor, ActorContext: ctx}
+	if ctx.props.Parent != nil {
+		ctx.actorSystem.SendSystemMessage(*ctx.props.Parent, SystemMessage{Type: SystemMessageFailure, Extras: failure})
+	} else {
+		ctx.props.RootStrategy().HandleFailure(ctx.actorSystem, ctx, failure)
+	}
+}
+
 func (ctx *ActorContext) HandleSystemMessage(msg SystemMessage) {
 
 	switch msg.Type {
 	case SystemMessageStart:
 		ctx.Start()
 	case SystemMessageStop:
-		ctx.Stop()
+		ctx.stop(msg.Extras)
 	case SystemMessageGracefulStop:
 		ctx.GracefulStop()
 	case SystemMessageChildTerminated:
@@ -157,7 +263,7 @@ func (ctx *ActorContext) HandleSystemMessage(msg SystemMessage) {
 			fmt.Println("System message extras not a Failure")
 		}
 	case SystemMessageRestart:
-		ctx.Restart()
+		ctx.restartWithReason(msg.Extras)
 	case SuspendMailbox:
 		//ignore
 		// fmt.Println("Suspend mailbox: ", ctx.self)
@@ -178,9 +284,20 @@ func (ctx *ActorContext) HandleSystemMessage(msg SystemMessage) {
 func (ctx *ActorContext) Start() {
 	ctx.state = actorStart
 	// fmt.Println("System message start:", ctx.self)
+
+	if ctx.restart != nil {
+		ctx.receiveLifecycle(*ctx.restart)
+		ctx.restart = nil
+	}
+	ctx.receiveLifecycle(Started{})
 }
 
 func (ctx *ActorContext) Restart() {
+	ctx.restartWithReason(nil)
+}
+
+// Stops children and respawns actor, reason is delivered with Restarting message
+func (ctx *ActorContext) restartWithReason(reason interface{}) {
 
 	ctx.mu.RLock()
 	if len(ctx.children) > 0 {
@@ -193,10 +310,16 @@ func (ctx *ActorContext) Restart() {
 	}
 
 	ctx.state = actorStop
-	ctx.actorSystem.RespawnActor(ctx.actor, ctx.self, ctx.actorChan, *ctx.props)
+	ctx.actorSystem.respawnActor(ctx, reason, *ctx.props)
 }
 
 func (ctx *ActorContext) Stop() {
+	ctx.stop(nil)
+}
+
+// Stops actor and its children, reason is passed to watchers
+func (ctx *ActorContext) stop(reason interface{}) {
+	ctx.receiveLifecycle(Stopping{})
 
 	ctx.mu.RLock()
 	if len(ctx.children) > 0 {
@@ -208,13 +331,29 @@ func (ctx *ActorContext) Stop() {
 		ctx.mu.RUnlock()
 	}
 
-	ctx.actorSystem.RemoveActor(ctx.self, SystemMessage{Type: DeleteMailbox})
+	ctx.remove(reason)
 	ctx.state = actorStop
+	ctx.receiveLifecycle(Stopped{})
 	// fmt.Println("System message stop", ctx.self)
 }
 
+// Cancels timers of context and returns envelopes it stashed
+func (ctx *ActorContext) release() []Envelope {
+	ctx.timers.cancelAll()
+	return ctx.stash.takeAll()
+}
+
+// Removes actor from actor system, stashed envelopes are dead letters
+func (ctx *ActorContext) remove(reason interface{}) {
+	for _, envelope := range ctx.release() {
+		ctx.actorSystem.deadLetters.publishEnvelope(envelope, DeadLetterActorStopped)
+	}
+	ctx.actorSystem.removeActor(ctx.self, SystemMessage{Type: DeleteMailbox}, reason)
+}
+
 func (ctx *ActorContext) GracefulStop() {
 	ctx.state = actorStopping
+	ctx.receiveLifecycle(Stopping{})
 	// fmt.Println("System message stopping", ctx.self)
 
 	ctx.mu.RLock()
@@ -230,8 +369,9 @@ func (ctx *ActorContext) GracefulStop() {
 			ctx.actorSystem.SendSystemMessage(*ctx.props.Parent, SystemMessage{Type: SystemMessageChildTerminated, Extras: ctx.self})
 		}
 		// fmt.Println("No children, actor stopped", ctx.self)
-		ctx.actorSystem.RemoveActor(ctx.self, SystemMessage{Type: DeleteMailbox})
+		ctx.remove(nil)
 		ctx.state = actorStop
+		ctx.receiveLifecycle(Stopped{})
 	}
 }
 
@@ -248,8 +388,9 @@ func (ctx *ActorContext) ChildTerminated(child PID) {
 		if ctx.props.Parent != nil {
 			ctx.actorSystem.SendSystemMessage(*ctx.props.Parent, SystemMessage{Type: SystemMessageChildTerminated, Extras: ctx.self})
 		}
-		ctx.actorSystem.RemoveActor(ctx.self, SystemMessage{Type: DeleteMailbox})
+		ctx.remove(nil)
 		ctx.state = actorStop
+		ctx.receiveLifecycle(Stopped{})
 		// fmt.Println("No more children left, actor stopping", ctx.self)
 	} else {
 		ctx.mu.RUnlock()
@@ -281,10 +422,11 @@ func (ctx *ActorContext) ResumeChildren() {
 }
 
 func (ctx *ActorContext) EscalateFailure(failure Failure) {
-	supervisorFailure := Failure{Reason: failure.Reason, Who: ctx.self, Actor: ctx.actor, ActorContext: ctx, ActorChan: ctx.actorChan}
+	supervisorFailure := Failure{Reason: failure.Reason, Who: ctx.self, Actor: ctx.actor, ActorContext: ctx}
 
 	switch failure.Reason.(type) {
 	case NotPanic:
+		ctx.actorSystem.eventStream.Publish(ActorFailed{Who: ctx.self, Reason: failure.Reason})
 		ctx.actorSystem.SendSystemMessage(ctx.self, SystemMessage{Type: SuspendMailbox})
 		ctx.SuspendChildren()
 
@@ -4,6 +4,11 @@ type ActorProps struct {
 	Parent              *PID
 	rootStrategy        FailureStrategy
 	supervisionStrategy FailureStrategy
+	stashCapacity       int
+	mailboxCapacity     int
+	overflowPolicy      OverflowPolicy
+	mailboxPriority     MailboxPriority
+	dispatcher          Dispatcher
 }
 
 func NewActorProps(parent *PID) *ActorProps {
@@ -41,6 +46,53 @@ func (prop *ActorProps) SetSupervisionStrategy(strategy FailureStrategy) {
 	prop.supervisionStrategy = strategy
 }
 
+// Sets max number of messages actor can stash
+func (prop *ActorProps) SetStashCapacity(capacity int) {
+	prop.stashCapacity = capacity
+}
+
+func (prop *ActorProps) StashCapacity() int {
+	if prop.stashCapacity <= 0 {
+		return defaultStashCapacity
+	}
+	return prop.stashCapacity
+}
+
+// Makes actor use mailbox with at most capacity user messages, policy decides what happens when it is full
+func (prop *ActorProps) SetBoundedMailbox(capacity int, policy OverflowPolicy) {
+	prop.mailboxCapacity = capacity
+	prop.overflowPolicy = policy
+}
+
+func (prop *ActorProps) MailboxCapacity() int {
+	return prop.mailboxCapacity
+}
+
+func (prop *ActorProps) OverflowPolicy() OverflowPolicy {
+	return prop.overflowPolicy
+}
+
+// Makes actor use priority mailbox, user messages implementing PriorityMessage are ordered by priority
+func (prop *ActorProps) SetPriorityMailbox(priority MailboxPriority) {
+	prop.mailboxPriority = priority
+}
+
+func (prop *ActorProps) MailboxPriority() MailboxPriority {
+	return prop.mailboxPriority
+}
+
+// Sets dispatcher that runs actor, default dispatcher starts goroutine whenever actor has messages
+func (prop *ActorProps) SetDispatcher(dispatcher Dispatcher) {
+	prop.dispatcher = dispatcher
+}
+
+func (prop *ActorProps) Dispatcher() Dispatcher {
+	if prop.dispatcher == nil {
+		return defaultDispatcher
+	}
+	return prop.dispatcher
+}
+
 func (prop *ActorProps) RootStrategy() FailureStrategy {
 	if prop.rootStrategy == nil {
 		return defaultRootStrategy
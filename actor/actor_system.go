@@ -2,50 +2,123 @@ package actor
 
 import (
 	"context"
-	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type ActorSystem struct {
-	registry *Registry
+	registry    *Registry
+	watches     *watchRegistry
+	eventStream *EventStream
+	deadLetters *DeadLetters
+	nextID      atomic.Uint64 // Used to name actors spawned without name
+	address     string        // Address of node, set when remote is enabled
+	remote      RemoteHandler
+}
+
+// RemoteHandler delivers envelopes addressed to actors on other nodes
+type RemoteHandler interface {
+	SendRemote(envelope Envelope) error
 }
 
 // Creates new actor system that can only be used localy
 func NewActorSystem() *ActorSystem {
-	return &ActorSystem{registry: NewRegistry()}
+	system := &ActorSystem{registry: NewRegistry(), watches: newWatchRegistry()}
+	system.eventStream = newEventStream(system)
+	system.deadLetters = newDeadLetters(system.eventStream)
+	return system
+}
+
+// EventStream returns event stream of actor system, used to subscribe to framework events and for pub/sub between actors
+func (system *ActorSystem) EventStream() *EventStream {
+	return system.eventStream
 }
 
+// Enables sending to actors on other nodes, address is address of this node.
+// It should be called before actors start sending messages
+func (system *ActorSystem) RegisterRemote(address string, handler RemoteHandler) {
+	system.address = address
+	system.remote = handler
+}
+
+// Address returns address of node, empty if remote is not enabled
+func (system *ActorSystem) Address() string {
+	return system.address
+}
+
+// IsLocal reports whether actor lives in this actor system
+func (system *ActorSystem) IsLocal(pid PID) bool {
+	return pid.Address == "" || pid.Address == system.address
+}
+
+// Registry returns registry of actor system, used to look up actors by path
+func (system *ActorSystem) Registry() *Registry {
+	return system.registry
+}
+
+// Spawns actor with generated name such as $1
 func (system *ActorSystem) SpawnActor(a Actor, props ...ActorProps) (PID, error) {
-	prop := ConfigureActorProps(props...)
+	name := "$" + strconv.FormatUint(system.nextID.Add(1), 10)
+	return system.spawn(name, a, ConfigureActorProps(props...))
+}
 
-	actorChan := make(chan Envelope)
-	mailbox := NewMailbox(actorChan)
+// Spawns actor with name, its path is path of parent followed by name or /user/name if it has no parent.
+// Error is returned if actor with same path already exists
+func (system *ActorSystem) SpawnNamed(name string, a Actor, props ...ActorProps) (PID, error) {
+	if err := validateName(name); err != nil {
+		return PID{}, err
+	}
+	return system.spawn(name, a, ConfigureActorProps(props...))
+}
 
-	mailboxChan := mailbox.GetChan()
+func (system *ActorSystem) spawn(name string, a Actor, prop *ActorProps) (PID, error) {
 	mailboxPID, err := NewPID()
 	if err != nil {
 		return mailboxPID, err
 	}
+	mailboxPID.Path = childPath(prop.Parent, name)
 
-	startMailbox(mailbox)
+	//Actor context processes messages taken from mailbox
+	mailbox := newMailboxFromProps(system.deadLetters, prop)
+	actorContext := NewActorContext(a, context.Background(), system, prop, mailboxPID, mailbox)
+	mailbox.RegisterInvoker(actorContext)
 
-	startActor(a, system, prop, mailboxPID, actorChan)
-
-	//Put mailbox chanel in registry
-	err = system.registry.Add(mailboxPID, mailboxChan)
+	//Put mailbox in registry
+	err = system.registry.Add(mailboxPID, mailbox)
 	if err != nil {
-		return mailboxPID, err
+		return PID{}, err
 	}
 
+	system.eventStream.Publish(ActorSpawned{Who: mailboxPID, Parent: prop.Parent})
+
+	system.SendSystemMessage(mailboxPID, SystemMessage{Type: SystemMessageStart})
+
 	return mailboxPID, nil
 }
 
-func (system *ActorSystem) RespawnActor(a Actor, mailboxPID PID, actorChan chan Envelope, props ...ActorProps) (PID, error) {
+// Respawns actor with new context, actor receives Restarting with reason before Started.
+// It is called while mailbox processes restart message, new context processes following messages
+func (system *ActorSystem) respawnActor(previous *ActorContext, reason interface{}, props ...ActorProps) (PID, error) {
 	prop := ConfigureActorProps(props...)
+	mailboxPID := previous.self
+	mailbox := previous.mailbox
+
+	//Timers of previous incarnation are cancelled, new context gets new timers.
+	//Stashed messages of previous incarnation are returned to mailbox
+	stashed := previous.release()
+	if len(stashed) > 0 {
+		system.SendSystemMessage(mailboxPID, SystemMessage{Type: UnstashMailbox, Extras: stashed})
+	}
 
-	actorContext := NewActorContext(a, context.Background(), system, prop, mailboxPID, actorChan)
+	actorContext := NewActorContext(previous.actor, context.Background(), system, prop, mailboxPID, mailbox)
+	actorContext.restart = &Restarting{Reason: reason}
 
-	//Start actor in separate gorutine
-	startActorWithContext(a, system, actorContext, actorChan)
+	system.eventStream.Publish(ActorRestarted{Who: mailboxPID, Reason: reason})
+
+	mailbox.RegisterInvoker(actorContext)
 
 	system.SendSystemMessage(actorContext.self, SystemMessage{Type: ResumeMailboxAll})
 	system.SendSystemMessage(actorContext.self, SystemMessage{Type: SystemMessageStart})
@@ -53,102 +126,62 @@ func (system *ActorSystem) RespawnActor(a Actor, mailboxPID PID, actorChan chan
 	return mailboxPID, nil
 }
 
-func startActor(a Actor, system *ActorSystem, prop *ActorProps, mailboxPID PID, actorChan chan Envelope) {
-	go func() {
-
-		//Setup basic actor context
-		actorContext := NewActorContext(a, context.Background(), system, prop, mailboxPID, actorChan)
-
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("Actor recovered, need error handling:", r)
-				system.SendSystemMessage(actorContext.self, SystemMessage{Type: SuspendMailbox})
-				actorContext.SuspendChildren()
-				if actorContext.Parent() != nil {
-					failure := Failure{Reason: r, Who: *actorContext.Self(), Actor: a, ActorContext: actorContext, ActorChan: actorChan}
-					system.SendSystemMessage(*actorContext.props.Parent, SystemMessage{Type: SystemMessageFailure, Extras: failure})
-				} else {
-					actorContext.props.rootStrategy.HandleFailure(system, actorContext, Failure{Reason: r, Who: *actorContext.Self(), Actor: a, ActorContext: actorContext, ActorChan: actorChan})
-				}
-			}
-		}()
-
-		system.SendSystemMessage(actorContext.self, SystemMessage{Type: SystemMessageStart})
-
-		for {
-			envelope := <-actorChan
-			//Set only message and send
-			actorContext.AddEnvelope(envelope)
-			a.Receive(*actorContext)
-
-			if msg, ok := envelope.Message.(SystemMessage); ok {
-				actorContext.HandleSystemMessage(msg)
-				if actorContext.state == actorStop {
-					return
-				}
-			}
-
-		}
-	}()
-}
-
-func startActorWithContext(a Actor, system *ActorSystem, actorContext *ActorContext, actorChan chan Envelope) {
-	go func() {
-
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("Actor recovered, need error handling:", r)
-				system.SendSystemMessage(actorContext.self, SystemMessage{Type: SuspendMailbox})
-				if actorContext.Parent() != nil {
-					failure := Failure{Reason: r, Who: *actorContext.Self(), Actor: a, ActorContext: actorContext, ActorChan: actorChan}
-
-					actorContext.SuspendChildren()
-					system.SendSystemMessage(*actorContext.props.Parent, SystemMessage{Type: SystemMessageFailure, Extras: failure})
-				} else {
-					actorContext.props.rootStrategy.HandleFailure(system, actorContext, Failure{Reason: r, Who: *actorContext.Self(), Actor: a, ActorContext: actorContext, ActorChan: actorChan})
-				}
-			}
-		}()
-
-		for {
-			envelope := <-actorChan
-
-			actorContext.AddEnvelope(envelope)
-			a.Receive(*actorContext)
-
-			if msg, ok := envelope.Message.(SystemMessage); ok {
-				actorContext.HandleSystemMessage(msg)
-				if actorContext.state == actorStop {
-					return
-				}
-			}
+// Sends envelope to receiver, error is returned only when bounded mailbox of receiver is full and its policy is OverflowFail
+func (system *ActorSystem) Send(envelope Envelope) error {
+	// fmt.Printf("Send message: %v to receiver: %v\n", envelope.Message, envelope.Receiver())
+	receiver := envelope.Receiver()
+	if receiver == nil {
+		system.deadLetters.publishEnvelope(envelope, DeadLetterUnknownReceiver)
+		return nil
+	}
+	if !system.IsLocal(*receiver) {
+		if system.remote == nil {
+			system.deadLetters.publishEnvelope(envelope, DeadLetterUnknownReceiver)
+			return nil
 		}
-	}()
+		return system.remote.SendRemote(envelope)
+	}
+	process := system.registry.Find(*receiver)
+	if process == nil {
+		// fmt.Println("Channel is nil")
+		system.deadLetters.publishEnvelope(envelope, DeadLetterUnknownReceiver)
+		return nil
+	}
+	return process.Send(envelope)
 }
 
-func startMailbox(mailbox *Mailbox) {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("Mailbox recovered, need restarting:", r)
-			}
-		}()
-		mailbox.Start()
-	}()
+// Returns number of messages dropped or rejected by mailbox of actor because it was full
+func (system *ActorSystem) MailboxOverflowCount(pid PID) int64 {
+	if mailbox, ok := system.registry.Find(pid).(*Mailbox); ok {
+		return mailbox.OverflowCount()
+	}
+	return 0
 }
 
-func (system *ActorSystem) Send(envelope Envelope) {
-	// fmt.Printf("Send message: %v to receiver: %v\n", envelope.Message, envelope.Receiver())
-	ch := system.registry.Find(*envelope.Receiver())
-	if ch == nil {
-		// fmt.Println("Channel is nil")
-		return
+// DeadLetters returns dead letters of actor system, used to subscribe to undeliverable messages
+func (system *ActorSystem) DeadLetters() *DeadLetters {
+	return system.deadLetters
+}
+
+// SendToDeadLetters publishes envelope that could not be delivered as dead letter
+func (system *ActorSystem) SendToDeadLetters(envelope Envelope, reason DeadLetterReason) {
+	system.deadLetters.publishEnvelope(envelope, reason)
+}
+
+// Sends message to receiver and returns future that completes with the reply.
+// Receiver replies by sending message to envelope sender
+func (system *ActorSystem) Ask(receiver PID, message interface{}, timeout time.Duration) *Future {
+	future := newFuture(system, timeout)
+	if future.pid.ID == uuid.Nil {
+		return future
 	}
-	ch <- envelope
+	system.Send(NewEnvelopeWithSender(message, receiver, future.pid))
+	return future
 }
 
-func (system *ActorSystem) AddRemoteActor(remoteActorPID PID, senderChan chan Envelope) {
-	system.registry.Add(remoteActorPID, senderChan)
+// Creates future that completes with first message sent to its PID, used for requests not sent with Ask
+func (system *ActorSystem) NewFuture(timeout time.Duration) *Future {
+	return newFuture(system, timeout)
 }
 
 func (system *ActorSystem) SendSystemMessage(receiver PID, msg SystemMessage) {
@@ -159,8 +192,7 @@ func (system *ActorSystem) SendSystemMessage(receiver PID, msg SystemMessage) {
 }
 
 func (system *ActorSystem) RemoveActor(receiver PID, msg SystemMessage) {
-	system.SendSystemMessage(receiver, msg)
-	system.registry.Remove(receiver)
+	system.removeActor(receiver, msg, nil)
 }
 
 func (system *ActorSystem) Stop(pid PID) {
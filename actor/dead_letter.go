@@ -0,0 +1,121 @@
+package actor
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type DeadLetterReason string
+
+const (
+	DeadLetterUnknownReceiver DeadLetterReason = "unknown receiver"
+	DeadLetterActorStopped    DeadLetterReason = "actor stopped"
+	DeadLetterNoSender        DeadLetterReason = "no sender to respond to"
+	DeadLetterMailboxFull     DeadLetterReason = "mailbox full"
+	DeadLetterFutureCompleted DeadLetterReason = "future already completed"
+)
+
+const (
+	deadLetterLogLimit    = 10 // Max number of dead letters logged per interval
+	deadLetterLogInterval = time.Second
+)
+
+// DeadLetter is published for every user message that could not be delivered
+type DeadLetter struct {
+	Message  interface{}
+	Receiver PID
+	Sender   *PID
+	Reason   DeadLetterReason
+}
+
+// DeadLetters publishes undeliverable messages to event stream, counts them and logs them with throttling
+type DeadLetters struct {
+	eventStream *EventStream
+	count       int64
+	counts      map[DeadLetterReason]int64
+	logStart    time.Time // Start of current logging interval
+	logged      int       // Dead letters logged in current interval
+	suppressed  int       // Dead letters not logged in current interval
+	mu          sync.Mutex
+}
+
+func newDeadLetters(eventStream *EventStream) *DeadLetters {
+	return &DeadLetters{
+		eventStream: eventStream,
+		counts:      make(map[DeadLetterReason]int64),
+	}
+}
+
+// Subscribe registers handler that is called for every dead letter
+func (d *DeadLetters) Subscribe(handler func(DeadLetter)) *Subscription {
+	return d.eventStream.Subscribe(func(event interface{}) {
+		handler(event.(DeadLetter))
+	}, isDeadLetter)
+}
+
+func (d *DeadLetters) Unsubscribe(subscription *Subscription) {
+	d.eventStream.Unsubscribe(subscription)
+}
+
+func isDeadLetter(event interface{}) bool {
+	_, ok := event.(DeadLetter)
+	return ok
+}
+
+// Count returns total number of dead letters
+func (d *DeadLetters) Count() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
+
+// CountByReason returns number of dead letters with given reason
+func (d *DeadLetters) CountByReason(reason DeadLetterReason) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.counts[reason]
+}
+
+func (d *DeadLetters) publish(deadLetter DeadLetter) {
+	d.mu.Lock()
+	d.count++
+	d.counts[deadLetter.Reason]++
+	d.logThrottled(deadLetter)
+	d.mu.Unlock()
+
+	d.eventStream.Publish(deadLetter)
+}
+
+// Logs at most deadLetterLogLimit dead letters per interval, must be called with lock held
+func (d *DeadLetters) logThrottled(deadLetter DeadLetter) {
+	now := time.Now()
+	if now.Sub(d.logStart) > deadLetterLogInterval {
+		if d.suppressed > 0 {
+			log.Printf("[DeadLetter] %d dead letters not logged", d.suppressed)
+		}
+		d.logStart = now
+		d.logged = 0
+		d.suppressed = 0
+	}
+
+	if d.logged >= deadLetterLogLimit {
+		d.suppressed++
+		return
+	}
+	d.logged++
+	log.Printf("[DeadLetter] message %T to %v was not delivered: %s", deadLetter.Message, deadLetter.Receiver, deadLetter.Reason)
+}
+
+// Publishes envelope as dead letter, system messages are internal and are not published
+func (d *DeadLetters) publishEnvelope(envelope Envelope, reason DeadLetterReason) {
+	if _, ok := envelope.Message.(SystemMessage); ok {
+		return
+	}
+	d.publish(DeadLetter{
+		Message:  envelope.Message,
+		Receiver: envelope.receiver,
+		Sender:   envelope.Sender(),
+		Reason:   reason,
+	})
+}
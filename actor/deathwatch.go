@@ -0,0 +1,114 @@
+package actor
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrActorNotFound          = errors.New("actor not found")
+	ErrRemoteWatchUnsupported = errors.New("watching actors on other nodes is not supported")
+)
+
+// Terminated is sent to every watcher when watched actor stops.
+// Reason is nil for normal stop, failure reason when actor is removed by supervision strategy
+// and ErrActorNotFound when watched actor did not exist.
+type Terminated struct {
+	Who    PID
+	Reason interface{}
+}
+
+type watchRegistry struct {
+	watchers map[PID]map[PID]bool // Watched actor -> actors watching it
+	watching map[PID]map[PID]bool // Watcher -> actors it watches
+	mu       sync.Mutex
+}
+
+func newWatchRegistry() *watchRegistry {
+	return &watchRegistry{
+		watchers: make(map[PID]map[PID]bool),
+		watching: make(map[PID]map[PID]bool),
+	}
+}
+
+func (w *watchRegistry) add(watcher PID, watched PID) {
+	if w.watchers[watched] == nil {
+		w.watchers[watched] = make(map[PID]bool)
+	}
+	w.watchers[watched][watcher] = true
+
+	if w.watching[watcher] == nil {
+		w.watching[watcher] = make(map[PID]bool)
+	}
+	w.watching[watcher][watched] = true
+}
+
+func (w *watchRegistry) remove(watcher PID, watched PID) {
+	delete(w.watchers[watched], watcher)
+	if len(w.watchers[watched]) == 0 {
+		delete(w.watchers, watched)
+	}
+
+	delete(w.watching[watcher], watched)
+	if len(w.watching[watcher]) == 0 {
+		delete(w.watching, watcher)
+	}
+}
+
+// Removes all watches of terminated actor and returns its watchers
+func (w *watchRegistry) terminate(pid PID) []PID {
+	for watched := range w.watching[pid] {
+		w.remove(pid, watched)
+	}
+
+	watchers := make([]PID, 0, len(w.watchers[pid]))
+	for watcher := range w.watchers[pid] {
+		watchers = append(watchers, watcher)
+	}
+	for _, watcher := range watchers {
+		w.remove(watcher, pid)
+	}
+	return watchers
+}
+
+func (system *ActorSystem) watch(watcher PID, watched PID) error {
+	if !system.IsLocal(watched) {
+		return ErrRemoteWatchUnsupported
+	}
+	system.watches.mu.Lock()
+	if system.registry.Find(watched) == nil {
+		system.watches.mu.Unlock()
+		system.Send(NewEnvelope(Terminated{Who: watched, Reason: ErrActorNotFound}, watcher))
+		return nil
+	}
+	system.watches.add(watcher, watched)
+	system.watches.mu.Unlock()
+	return nil
+}
+
+func (system *ActorSystem) unwatch(watcher PID, watched PID) {
+	system.watches.mu.Lock()
+	system.watches.remove(watcher, watched)
+	system.watches.mu.Unlock()
+}
+
+// Removes actor from registry and notifies its watchers
+func (system *ActorSystem) removeActor(receiver PID, msg SystemMessage, reason interface{}) {
+	system.SendSystemMessage(receiver, msg)
+
+	system.watches.mu.Lock()
+	err := system.registry.Remove(receiver)
+	if err != nil {
+		// actor already removed, watchers are notified
+		system.watches.mu.Unlock()
+		return
+	}
+	watchers := system.watches.terminate(receiver)
+	system.watches.mu.Unlock()
+
+	system.eventStream.Publish(ActorStopped{Who: receiver, Reason: reason})
+
+	for _, watcher := range watchers {
+		system.Send(NewEnvelope(Terminated{Who: receiver, Reason: reason}, watcher))
+	}
+}
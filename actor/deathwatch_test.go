@@ -0,0 +1,42 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// watchingActor watches PIDs it receives and reports result of Watch
+type watchingActor struct {
+	results chan error
+}
+
+func (a *watchingActor) Receive(ctx ActorContext) {
+	if pid, ok := ctx.Message().(PID); ok {
+		a.results <- ctx.Watch(pid)
+	}
+}
+
+func TestWatchRemoteActorUnsupported(t *testing.T) {
+	system := NewActorSystem()
+	results := make(chan error, 1)
+	watcher, err := system.SpawnActor(&watchingActor{results: results})
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+	remote, err := NewPID()
+	if err != nil {
+		t.Fatalf("failed to create pid: %v", err)
+	}
+	remote.Address = "127.0.0.1:8091"
+
+	system.Send(NewEnvelope(remote, watcher))
+	select {
+	case err := <-results:
+		if !errors.Is(err, ErrRemoteWatchUnsupported) {
+			t.Fatalf("expected ErrRemoteWatchUnsupported, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch result was not reported")
+	}
+}
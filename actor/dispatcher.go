@@ -0,0 +1,210 @@
+package actor
+
+import "sync"
+
+// Dispatcher runs mailbox processing, Throughput is number of messages mailbox processes before it yields
+type Dispatcher interface {
+	Schedule(fn func())
+	Throughput() int
+}
+
+var defaultDispatcher = NewDefaultDispatcher(defaultThroughput)
+
+type goroutineDispatcher struct {
+	throughput int
+}
+
+// Starts new goroutine every time mailbox has work, used when actor props have no dispatcher
+func NewDefaultDispatcher(throughput int) *goroutineDispatcher {
+	if throughput <= 0 {
+		throughput = defaultThroughput
+	}
+	return &goroutineDispatcher{throughput: throughput}
+}
+
+func (d *goroutineDispatcher) Schedule(fn func()) {
+	go fn()
+}
+
+func (d *goroutineDispatcher) Throughput() int {
+	return d.throughput
+}
+
+// Runs mailboxes on fixed number of goroutines, scheduled mailboxes wait in queue until worker is free
+type workerPoolDispatcher struct {
+	throughput int
+	tasks      []func()
+	stopped    bool
+	cond       *sync.Cond
+	mu         sync.Mutex
+}
+
+// Creates dispatcher with workers goroutines shared by all actors that use it.
+// Actors running on pool should not block, blocked actor holds worker for others
+func NewWorkerPoolDispatcher(workers int, throughput int) *workerPoolDispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	if throughput <= 0 {
+		throughput = defaultThroughput
+	}
+	d := &workerPoolDispatcher{throughput: throughput}
+	d.cond = sync.NewCond(&d.mu)
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+func (d *workerPoolDispatcher) Schedule(fn func()) {
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		// mailbox still has to be processed
+		go fn()
+		return
+	}
+	d.tasks = append(d.tasks, fn)
+	d.mu.Unlock()
+	d.cond.Signal()
+}
+
+func (d *workerPoolDispatcher) Throughput() int {
+	return d.throughput
+}
+
+// Stops workers once queued tasks are done, tasks scheduled after that run on new goroutines
+func (d *workerPoolDispatcher) Stop() {
+	d.mu.Lock()
+	d.stopped = true
+	d.mu.Unlock()
+	d.cond.Broadcast()
+}
+
+func (d *workerPoolDispatcher) work() {
+	for {
+		d.mu.Lock()
+		for len(d.tasks) == 0 && !d.stopped {
+			d.cond.Wait()
+		}
+		if len(d.tasks) == 0 {
+			d.mu.Unlock()
+			return
+		}
+		task := d.tasks[0]
+		d.tasks[0] = nil
+		d.tasks = d.tasks[1:]
+		d.mu.Unlock()
+
+		task()
+	}
+}
+
+// Runs mailbox on single dedicated goroutine, meant for actors that block on I/O
+type pinnedDispatcher struct {
+	throughput int
+	tasks      []func()
+	wake       chan struct{} // Signals goroutine that tasks were added
+	once       sync.Once
+	stop       chan struct{}
+	stopped    bool
+	mu         sync.Mutex
+}
+
+// Creates dispatcher with its own goroutine, which is started on first schedule.
+// Every actor should get its own pinned dispatcher, actors sharing it share the goroutine
+func NewPinnedDispatcher(throughput int) *pinnedDispatcher {
+	if throughput <= 0 {
+		throughput = defaultThroughput
+	}
+	return &pinnedDispatcher{
+		throughput: throughput,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Queues task without blocking, mailbox schedules itself from dedicated goroutine when it yields
+func (d *pinnedDispatcher) Schedule(fn func()) {
+	d.once.Do(func() {
+		go d.work()
+	})
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		go fn()
+		return
+	}
+	d.tasks = append(d.tasks, fn)
+	d.mu.Unlock()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *pinnedDispatcher) Throughput() int {
+	return d.throughput
+}
+
+// Stops dedicated goroutine, should be called after actor is stopped.
+// Tasks that were not run yet and tasks scheduled after that run on new goroutines
+func (d *pinnedDispatcher) Stop() {
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		return
+	}
+	d.stopped = true
+	tasks := d.tasks
+	d.tasks = nil
+	d.mu.Unlock()
+	close(d.stop)
+	for _, task := range tasks {
+		go task()
+	}
+}
+
+func (d *pinnedDispatcher) work() {
+	for {
+		select {
+		case <-d.wake:
+		case <-d.stop:
+			return
+		}
+		for {
+			d.mu.Lock()
+			if len(d.tasks) == 0 || d.stopped {
+				d.mu.Unlock()
+				break
+			}
+			task := d.tasks[0]
+			d.tasks[0] = nil
+			d.tasks = d.tasks[1:]
+			d.mu.Unlock()
+
+			task()
+		}
+	}
+}
+
+type synchronousDispatcher struct {
+	throughput int
+}
+
+// Runs mailbox on goroutine of sender, message is processed before Send returns unless
+// receiver is already processing. Meant for deterministic tests
+func NewSynchronousDispatcher(throughput int) *synchronousDispatcher {
+	if throughput <= 0 {
+		throughput = defaultThroughput
+	}
+	return &synchronousDispatcher{throughput: throughput}
+}
+
+func (d *synchronousDispatcher) Schedule(fn func()) {
+	fn()
+}
+
+func (d *synchronousDispatcher) Throughput() int {
+	return d.throughput
+}
@@ -0,0 +1,136 @@
+package actor
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func propsWithDispatcher(dispatcher Dispatcher) ActorProps {
+	props := NewActorProps(nil)
+	props.SetDispatcher(dispatcher)
+	return *props
+}
+
+// eventActor records string messages it receives, ping is forwarded to peer
+type eventActor struct {
+	name   string
+	peer   *PID
+	events *[]string
+}
+
+func (a *eventActor) Receive(ctx ActorContext) {
+	msg, ok := ctx.Message().(string)
+	if !ok {
+		return
+	}
+	*a.events = append(*a.events, a.name+":"+msg)
+	if msg == "ping" && a.peer != nil {
+		ctx.Send("ping", *a.peer)
+		*a.events = append(*a.events, a.name+":sent")
+	}
+	if msg == "ping" && a.peer == nil {
+		ctx.Send("pong", *ctx.Sender())
+	}
+}
+
+func TestSynchronousDispatcherOrder(t *testing.T) {
+	system := NewActorSystem()
+	dispatcher := NewSynchronousDispatcher(0)
+	var events []string
+
+	second, err := system.SpawnActor(&eventActor{name: "b", events: &events}, propsWithDispatcher(dispatcher))
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+	first, err := system.SpawnActor(&eventActor{name: "a", peer: &second, events: &events}, propsWithDispatcher(dispatcher))
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+
+	// b runs inside send of a, pong waits until a is done with ping
+	system.Send(NewEnvelope("ping", first))
+	expected := []string{"a:ping", "b:ping", "a:sent", "a:pong"}
+	if len(events) != len(expected) {
+		t.Fatalf("events %v, expected %v", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("events %v, expected %v", events, expected)
+		}
+	}
+}
+
+// busyActor keeps sending messages to itself
+type busyActor struct {
+	processed int
+}
+
+func (a *busyActor) Receive(ctx ActorContext) {
+	if _, ok := ctx.Message().(string); ok {
+		a.processed++
+		ctx.Send("again", *ctx.Self())
+	}
+}
+
+type signalActor struct {
+	received chan struct{}
+}
+
+func (a *signalActor) Receive(ctx ActorContext) {
+	if _, ok := ctx.Message().(string); ok {
+		a.received <- struct{}{}
+	}
+}
+
+func TestWorkerPoolBusyActorDoesNotStarveOthers(t *testing.T) {
+	system := NewActorSystem()
+	dispatcher := NewWorkerPoolDispatcher(1, 10)
+	defer dispatcher.Stop()
+
+	busy, err := system.SpawnActor(&busyActor{}, propsWithDispatcher(dispatcher))
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+	defer system.Stop(busy)
+	received := make(chan struct{}, 1)
+	other, err := system.SpawnActor(&signalActor{received: received}, propsWithDispatcher(dispatcher))
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+
+	system.Send(NewEnvelope("start", busy))
+	system.Send(NewEnvelope("hello", other))
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("actor sharing worker with busy actor never ran")
+	}
+}
+
+func TestDispatcherStopFallsBackToGoroutine(t *testing.T) {
+	tests := map[string]interface {
+		Dispatcher
+		Stop()
+	}{
+		"worker pool": NewWorkerPoolDispatcher(1, 0),
+		"pinned":      NewPinnedDispatcher(0),
+	}
+	for name, dispatcher := range tests {
+		t.Run(name, func(t *testing.T) {
+			var before sync.WaitGroup
+			before.Add(1)
+			dispatcher.Schedule(before.Done)
+			before.Wait()
+
+			dispatcher.Stop()
+			done := make(chan struct{})
+			dispatcher.Schedule(func() { close(done) })
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("task scheduled after stop did not run")
+			}
+		})
+	}
+}
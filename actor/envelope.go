@@ -7,6 +7,7 @@ import (
 type Envelope struct {
 	Message  interface{}
 	receiver PID
+	sender   PID
 }
 
 func NewEnvelope(message interface{}, receiver PID) Envelope {
@@ -16,6 +17,15 @@ func NewEnvelope(message interface{}, receiver PID) Envelope {
 	}
 }
 
+// Creates envelope with sender, receiver can reply to sender
+func NewEnvelopeWithSender(message interface{}, receiver PID, sender PID) Envelope {
+	return Envelope{
+		Message:  message,
+		receiver: receiver,
+		sender:   sender,
+	}
+}
+
 func (e *Envelope) Receiver() *PID {
 	if e.receiver.ID == uuid.Nil {
 		return nil
@@ -23,6 +33,14 @@ func (e *Envelope) Receiver() *PID {
 	return &e.receiver
 }
 
+// Sender returns PID that should receive reply, nil if there is none
+func (e *Envelope) Sender() *PID {
+	if e.sender.ID == uuid.Nil {
+		return nil
+	}
+	return &e.sender
+}
+
 func (e *Envelope) Unwrap() (interface{}, *PID) {
 
 	var receiver *PID
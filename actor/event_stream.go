@@ -0,0 +1,132 @@
+package actor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ActorSpawned is published when actor is spawned
+type ActorSpawned struct {
+	Who    PID
+	Parent *PID
+}
+
+// ActorFailed is published when actor fails, before failure is handled by strategy
+type ActorFailed struct {
+	Who    PID
+	Reason interface{}
+}
+
+// ActorRestarted is published when actor is restarted by strategy
+type ActorRestarted struct {
+	Who    PID
+	Reason interface{}
+}
+
+// ActorStopped is published when actor is removed from actor system
+type ActorStopped struct {
+	Who    PID
+	Reason interface{}
+}
+
+// SupervisorDirective is published when decider of strategy chooses directive for failure
+type SupervisorDirective struct {
+	Who       PID
+	Reason    interface{}
+	Directive Directive
+}
+
+// Subscription is returned on subscribe and used to unsubscribe
+type Subscription struct {
+	id int
+}
+
+type subscriber struct {
+	id      int
+	handler func(event interface{})
+	pid     *PID
+	filter  func(event interface{}) bool
+}
+
+// EventStream publishes framework and user events to subscribers within actor system.
+// Handlers are called synchronously from publishing gorutine, actor subscribers receive events as messages
+type EventStream struct {
+	actorSystem *ActorSystem
+	subscribers map[int]*subscriber
+	snapshot    atomic.Pointer[[]*subscriber] // Copy of subscribers read by Publish, replaced on every change
+	nextID      int
+	mu          sync.Mutex
+}
+
+func newEventStream(actorSystem *ActorSystem) *EventStream {
+	return &EventStream{
+		actorSystem: actorSystem,
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+// Subscribe registers handler for events accepted by filter, nil filter accepts all events
+func (es *EventStream) Subscribe(handler func(event interface{}), filter func(event interface{}) bool) *Subscription {
+	return es.subscribe(&subscriber{handler: handler, filter: filter})
+}
+
+// SubscribePID sends events accepted by filter to actor, nil filter accepts all events.
+// Subscription is removed once actor is stopped
+func (es *EventStream) SubscribePID(pid PID, filter func(event interface{}) bool) *Subscription {
+	return es.subscribe(&subscriber{pid: &pid, filter: filter})
+}
+
+func (es *EventStream) subscribe(sub *subscriber) *Subscription {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.nextID++
+	sub.id = es.nextID
+	es.subscribers[sub.id] = sub
+	es.updateSnapshot()
+	return &Subscription{id: sub.id}
+}
+
+func (es *EventStream) Unsubscribe(subscription *Subscription) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if _, exists := es.subscribers[subscription.id]; !exists {
+		return
+	}
+	delete(es.subscribers, subscription.id)
+	es.updateSnapshot()
+}
+
+// Must be called with lock held
+func (es *EventStream) updateSnapshot() {
+	subscribers := make([]*subscriber, 0, len(es.subscribers))
+	for _, sub := range es.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	es.snapshot.Store(&subscribers)
+}
+
+// Publish delivers event to every subscriber whose filter accepts it
+func (es *EventStream) Publish(event interface{}) {
+	subscribers := es.snapshot.Load()
+	if subscribers == nil {
+		return
+	}
+
+	for _, sub := range *subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+
+		if sub.pid == nil {
+			sub.handler(event)
+			continue
+		}
+
+		if es.actorSystem.registry.Find(*sub.pid) == nil {
+			// actor is gone, sending to it would only produce more dead letters
+			es.Unsubscribe(&Subscription{id: sub.id})
+			continue
+		}
+		es.actorSystem.Send(NewEnvelope(event, *sub.pid))
+	}
+}
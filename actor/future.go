@@ -0,0 +1,110 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrTimeout = errors.New("future: timeout")
+
+// ReplyError is sent to future instead of reply when request could not be handled, future completes with Err
+type ReplyError struct {
+	Err error
+}
+
+// Future holds the reply of a request sent with Ask or Request.
+// Reply is received by temporary PID that is removed from registry once future completes.
+type Future struct {
+	pid    PID
+	system *ActorSystem
+	done   chan struct{}
+	once   sync.Once
+	result interface{}
+	err    error
+}
+
+func newFuture(system *ActorSystem, timeout time.Duration) *Future {
+	future := &Future{
+		system: system,
+		done:   make(chan struct{}),
+	}
+
+	pid, err := NewPID()
+	if err != nil {
+		future.complete(nil, err)
+		return future
+	}
+	future.pid = pid
+
+	replyChan := make(chan Envelope, 1)
+	err = system.registry.Add(pid, chanProcess{ch: replyChan, deadLetters: system.deadLetters})
+	if err != nil {
+		future.complete(nil, err)
+		return future
+	}
+
+	go future.await(replyChan, timeout)
+
+	return future
+}
+
+// Waits for reply, timeout or completion by Wait, timeout of zero waits forever
+func (f *Future) await(replyChan chan Envelope, timeout time.Duration) {
+	var timeoutChan <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+
+	select {
+	case envelope := <-replyChan:
+		if reply, ok := envelope.Message.(ReplyError); ok {
+			f.complete(nil, reply.Err)
+		} else {
+			f.complete(envelope.Message, nil)
+		}
+	case <-timeoutChan:
+		f.complete(nil, ErrTimeout)
+	case <-f.done:
+	}
+
+	f.system.registry.Remove(f.pid)
+}
+
+func (f *Future) complete(result interface{}, err error) {
+	f.once.Do(func() {
+		f.result = result
+		f.err = err
+		close(f.done)
+	})
+}
+
+// PID returns temporary PID the reply should be sent to
+func (f *Future) PID() PID {
+	return f.pid
+}
+
+// Result blocks until reply is received or future times out
+func (f *Future) Result() (interface{}, error) {
+	<-f.done
+	return f.result, f.err
+}
+
+// Wait blocks until reply is received, future times out or ctx is done. Future completes with
+// error of ctx when ctx is done first, reply received later is not delivered
+func (f *Future) Wait(ctx context.Context) (interface{}, error) {
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		f.complete(nil, ctx.Err())
+	}
+	return f.Result()
+}
+
+// Done returns channel that is closed when future completes
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
@@ -0,0 +1,47 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFutureWaitCanceledRemovesPID(t *testing.T) {
+	system := NewActorSystem()
+	future := system.NewFuture(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := future.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for system.Registry().Find(future.PID()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("pid of canceled future is still registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFutureExtraReplyIsDeadLetter(t *testing.T) {
+	system := NewActorSystem()
+	future := system.NewFuture(0)
+	process := system.Registry().Find(future.PID())
+	if process == nil {
+		t.Fatal("pid of future is not registered")
+	}
+
+	// reply channel holds one envelope, sends do not wait for future to take it
+	for _, reply := range []string{"first", "second", "third"} {
+		process.Send(NewEnvelope(reply, future.PID()))
+	}
+	result, err := future.Result()
+	if err != nil || result != "first" {
+		t.Fatalf("future completed with %v, %v, expected first", result, err)
+	}
+	if count := system.DeadLetters().CountByReason(DeadLetterFutureCompleted); count < 1 {
+		t.Fatal("extra reply was not published as dead letter")
+	}
+}
@@ -0,0 +1,31 @@
+package actor
+
+// LifecycleMessage is implemented by messages delivered to actor on lifecycle events
+type LifecycleMessage interface {
+	lifecycleMessage()
+}
+
+// Started is received when actor starts, both on first start and after restart
+type Started struct{}
+
+// Restarting is received before Started when actor is restarted
+type Restarting struct {
+	Reason interface{}
+}
+
+// Stopping is received when actor begins to stop, before its children are stopped
+type Stopping struct{}
+
+// Stopped is received after actor is removed, it is the last message actor receives
+type Stopped struct{}
+
+func (Started) lifecycleMessage()    {}
+func (Restarting) lifecycleMessage() {}
+func (Stopping) lifecycleMessage()   {}
+func (Stopped) lifecycleMessage()    {}
+
+// Delivers lifecycle message to actor Receive
+func (ctx *ActorContext) receiveLifecycle(message LifecycleMessage) {
+	ctx.AddEnvelope(NewEnvelope(message, ctx.self))
+	ctx.actor.Receive(*ctx)
+}
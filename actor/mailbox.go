@@ -1,149 +1,343 @@
 package actor
 
-type mailboxState int32
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ErrMailboxFull = errors.New("mailbox is full")
+
+const defaultThroughput = 300
+
+// OverflowPolicy decides what bounded mailbox does with user message when it is full
+type OverflowPolicy int
 
 const (
-	mailboxRunning mailboxState = iota
-	mailboxSuspended
+	OverflowBlock      OverflowPolicy = iota // Sender waits until there is space in mailbox
+	OverflowDropNewest                       // New message is dropped
+	OverflowDropOldest                       // Oldest queued message is dropped to make space for new one, lowest priority one in priority mailbox
+	OverflowFail                             // Send returns ErrMailboxFull
 )
 
+// MessageInvoker processes envelopes taken from mailbox, it is called by one goroutine at a time
+type MessageInvoker interface {
+	InvokeSystemMessage(envelope Envelope)
+	InvokeUserMessage(envelope Envelope)
+	HandlePanic(reason interface{})
+}
+
+const (
+	mailboxIdle int32 = iota
+	mailboxScheduled
+)
+
+// Mailbox holds system and user messages in lock-free queues. Goroutine that processes them
+// is started only when mailbox has messages, system messages are always processed first
 type Mailbox struct {
-	actorChan      chan Envelope
-	mailboxChan    chan Envelope
-	queue          []Envelope
-	suspendedQueue []Envelope
-	state          mailboxState
+	systemQueue   mailboxQueue
+	userQueue     mailboxQueue
+	unstashed     []Envelope // Envelopes returned by UnstashAll, processed before user queue
+	invoker       MessageInvoker
+	status        atomic.Int32 // mailboxIdle or mailboxScheduled
+	suspended     atomic.Bool  // Only system messages are processed, changed only by processing goroutine
+	deleted       atomic.Bool
+	systemCount   atomic.Int64 // System messages in queue
+	dispatcher    Dispatcher
+	deadLetters   *DeadLetters
+	capacity      int            // Max number of user messages, zero means unbounded
+	policy        OverflowPolicy // Used when mailbox is bounded and full
+	userCount     atomic.Int64   // User messages accepted and not yet passed to actor
+	overflowCount atomic.Int64   // User messages rejected or dropped because mailbox was full
+	space         chan struct{}  // Signals blocked senders that message left mailbox
+	done          chan struct{}  // Closed when mailbox is deleted
 }
 
-func NewMailbox(actorChan chan Envelope) *Mailbox {
+func NewMailbox(deadLetters *DeadLetters) *Mailbox {
 	m := &Mailbox{
-		actorChan:      actorChan,
-		mailboxChan:    make(chan Envelope),
-		queue:          make([]Envelope, 0),
-		suspendedQueue: make([]Envelope, 0),
-		state:          mailboxSuspended,
+		systemQueue: newMPSCQueue(),
+		userQueue:   newMPSCQueue(),
+		dispatcher:  defaultDispatcher,
+		deadLetters: deadLetters,
+		space:       make(chan struct{}, 1),
+		done:        make(chan struct{}),
 	}
 	return m
 }
 
-func (m *Mailbox) buffer(envelope Envelope) {
-	if m.state == mailboxRunning {
-		m.queue = append(m.queue, envelope)
-		return
-	} else if m.state == mailboxSuspended {
-		switch envelope.Message.(type) {
-		case SystemMessage:
-			m.queue = append(m.queue, envelope)
-			return
-		default:
-			// not adding envelope to buffer
+// Creates mailbox that holds at most capacity user messages, system messages are never limited
+func NewBoundedMailbox(deadLetters *DeadLetters, capacity int, policy OverflowPolicy) *Mailbox {
+	m := NewMailbox(deadLetters)
+	m.capacity = capacity
+	m.policy = policy
+	return m
+}
+
+// Sets invoker that processes messages, it has to be registered before first message is sent to mailbox.
+// Actor replaces invoker on restart while processing messages
+func (m *Mailbox) RegisterInvoker(invoker MessageInvoker) {
+	m.invoker = invoker
+}
+
+// Sets dispatcher that runs processing of mailbox, it has to be set before first message is sent to mailbox
+func (m *Mailbox) SetDispatcher(dispatcher Dispatcher) {
+	m.dispatcher = dispatcher
+}
+
+// Send puts envelope in mailbox applying overflow policy if mailbox is bounded.
+// With OverflowBlock actor must not send to itself when its mailbox is full
+func (m *Mailbox) Send(envelope Envelope) error {
+	if _, ok := envelope.Message.(SystemMessage); ok {
+		if m.deleted.Load() {
+			return nil
 		}
+		m.systemCount.Add(1)
+		m.systemQueue.push(envelope)
+		m.schedule()
+		return nil
 	}
 
+	if m.deleted.Load() {
+		m.deadLetters.publishEnvelope(envelope, DeadLetterActorStopped)
+		return nil
+	}
+	if m.capacity > 0 {
+		if accepted, err := m.admit(envelope); !accepted {
+			return err
+		}
+	} else {
+		m.userCount.Add(1)
+	}
+	m.userQueue.push(envelope)
+	m.schedule()
+	return nil
 }
 
-func (m *Mailbox) getEnvelope() Envelope {
-	defer func() {
-		m.queue = m.queue[1:]
-	}()
-	return m.queue[0]
+// Reserves place for user message in bounded mailbox, envelope that is not accepted is dropped or rejected
+func (m *Mailbox) admit(envelope Envelope) (bool, error) {
+	for {
+		count := m.userCount.Load()
+		if count < int64(m.capacity) || m.policy == OverflowDropOldest {
+			if m.userCount.CompareAndSwap(count, count+1) {
+				if count+1 < int64(m.capacity) {
+					// pass signal to next blocked sender
+					m.signalSpace()
+				}
+				return true, nil
+			}
+			continue
+		}
+		switch m.policy {
+		case OverflowDropNewest:
+			m.overflowCount.Add(1)
+			m.deadLetters.publishEnvelope(envelope, DeadLetterMailboxFull)
+			return false, nil
+		case OverflowFail:
+			m.overflowCount.Add(1)
+			return false, ErrMailboxFull
+		}
+		select {
+		case <-m.space:
+		case <-m.done:
+			m.deadLetters.publishEnvelope(envelope, DeadLetterActorStopped)
+			return false, nil
+		}
+	}
+}
+
+func (m *Mailbox) signalSpace() {
+	select {
+	case m.space <- struct{}{}:
+	default:
+	}
+}
+
+// Called when user message leaves mailbox
+func (m *Mailbox) release() {
+	m.userCount.Add(-1)
+	if m.capacity > 0 {
+		m.signalSpace()
+	}
+}
+
+// Starts processing goroutine if it is not already running
+func (m *Mailbox) schedule() {
+	if m.status.CompareAndSwap(mailboxIdle, mailboxScheduled) {
+		m.dispatcher.Schedule(m.run)
+	}
 }
 
-func (m *Mailbox) Start() {
-	m.state = mailboxRunning
-	var newEnvelope Envelope
-	var haveReady bool = false
+// Processes messages, once throughput is reached mailbox is scheduled again so other
+// mailboxes on same dispatcher can run
+func (m *Mailbox) run() {
 	for {
-		for haveReady {
-			if m.state == mailboxSuspended {
-
-				select {
-				case m.actorChan <- newEnvelope:
-					if len(m.queue) > 0 {
-						newEnvelope = m.getEnvelope()
-					} else {
-						haveReady = false
-					}
-				case envelope := <-m.mailboxChan:
-					switch msg := envelope.Message.(type) {
-					case SystemMessage:
-						if msg.Type == DeleteMailbox {
-							m.delete()
-							return
-						}
-						m.buffer(envelope)
-					default:
-						// add to suspended queue
-						m.suspendedQueue = append(m.suspendedQueue, envelope)
-					}
-				}
+		yielded := m.process()
 
-			} else {
-				if len(m.suspendedQueue) > 0 {
-					m.queue = append(m.queue, m.suspendedQueue...)
-					m.suspendedQueue = m.suspendedQueue[1:]
-				}
-				select {
-				case m.actorChan <- newEnvelope:
-					if len(m.queue) > 0 {
-						newEnvelope = m.getEnvelope()
-					} else {
-						haveReady = false
-					}
-				case envelope := <-m.mailboxChan:
-					switch msg := envelope.Message.(type) {
-					case SystemMessage:
-						if msg.Type == DeleteMailbox {
-							// fmt.Println("Delete mailbox")
-							m.delete()
-							return
-						} else if msg.Type == SuspendMailbox || msg.Type == SuspendMailboxAll || msg.Type == SystemMessageGracefulStop {
-							m.state = mailboxSuspended
-							// fmt.Println("Suspend mailbox: ", m.state)
-						} else if msg.Type == ResumeMailbox || msg.Type == ResumeMailboxAll {
-							m.state = mailboxRunning
-							// fmt.Println("Resume mailbox: ", m.state)
-						}
-					}
-					m.buffer(envelope)
-				}
-			}
+		m.status.Store(mailboxIdle)
+		// message could be added after process returned and before status was changed
+		if !m.hasMessages() || !m.status.CompareAndSwap(mailboxIdle, mailboxScheduled) {
+			return
+		}
+		if yielded {
+			m.dispatcher.Schedule(m.run)
+			return
+		}
+	}
+}
 
+// Processes messages until mailbox is empty or throughput is reached, returns true in latter case.
+// Panic in invoker is handled by invoker and stops processing
+func (m *Mailbox) process() (yielded bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.invoker.HandlePanic(r)
 		}
-		newEnvelope = <-m.mailboxChan
-		switch msg := newEnvelope.Message.(type) {
-		case SystemMessage:
-			if msg.Type == DeleteMailbox {
-				// fmt.Println("Delete mailbox")
-				m.delete()
-				return
-			} else if msg.Type == SuspendMailbox || msg.Type == SuspendMailboxAll || msg.Type == SystemMessageGracefulStop {
-				m.state = mailboxSuspended
-				// fmt.Println("Suspend mailbox: ", m.state)
-			} else if msg.Type == ResumeMailbox || msg.Type == ResumeMailboxAll {
-				m.state = mailboxRunning
-				// fmt.Println("Resume mailbox: ", m.state)
-			}
+	}()
+
+	throughput := m.dispatcher.Throughput()
+	for processed := 0; ; processed++ {
+		if processed >= throughput {
+			return true
 		}
-		if m.state == mailboxRunning {
-			haveReady = true
-		} else {
-			switch newEnvelope.Message.(type) {
-			case SystemMessage:
-				haveReady = true
-			default:
-				m.suspendedQueue = append(m.suspendedQueue, newEnvelope)
+
+		if m.deleted.Load() {
+			m.drain()
+			return false
+		}
+
+		if envelope, ok := m.systemQueue.pop(); ok {
+			m.systemCount.Add(-1)
+			if m.handleSystemMessage(envelope.Message.(SystemMessage)) {
+				m.invoker.InvokeSystemMessage(envelope)
 			}
+			continue
 		}
 
+		if m.suspended.Load() {
+			return false
+		}
+
+		m.dropOldest()
+		envelope, ok := m.popUser()
+		if !ok {
+			return false
+		}
+		m.release()
+		m.invoker.InvokeUserMessage(envelope)
+	}
+}
+
+// Changes mailbox state, returns false if message is handled only by mailbox
+func (m *Mailbox) handleSystemMessage(msg SystemMessage) bool {
+	switch msg.Type {
+	case DeleteMailbox:
+		m.delete()
+		return false
+	case UnstashMailbox:
+		m.unstash(msg)
+		return false
+	case SuspendMailbox, SuspendMailboxAll, SystemMessageGracefulStop:
+		m.suspended.Store(true)
+	case ResumeMailbox, ResumeMailboxAll:
+		m.suspended.Store(false)
 	}
+	return true
 }
 
-func (m *Mailbox) GetChan() chan Envelope {
-	return m.mailboxChan
+// Stops processing of user messages until mailbox is resumed, must be called by processing goroutine
+func (m *Mailbox) suspend() {
+	m.suspended.Store(true)
 }
 
+// Uses only counters, processing goroutine could already be started by sender
+func (m *Mailbox) hasMessages() bool {
+	if m.systemCount.Load() > 0 {
+		return true
+	}
+	if m.suspended.Load() && !m.deleted.Load() {
+		return false
+	}
+	return m.userCount.Load() > 0
+}
+
+func (m *Mailbox) popUser() (Envelope, bool) {
+	if len(m.unstashed) > 0 {
+		envelope := m.unstashed[0]
+		m.unstashed = m.unstashed[1:]
+		return envelope, true
+	}
+	return m.userQueue.pop()
+}
+
+// Takes user message that is dropped when mailbox overflows
+func (m *Mailbox) popDropped() (Envelope, bool) {
+	if queue, ok := m.userQueue.(*priorityQueue); ok {
+		if envelope, ok := queue.popLowest(); ok {
+			return envelope, true
+		}
+	}
+	return m.popUser()
+}
+
+// Puts unstashed envelopes in front of envelopes already in mailbox
+func (m *Mailbox) unstash(msg SystemMessage) {
+	envelopes, ok := msg.Extras.([]Envelope)
+	if !ok {
+		return
+	}
+	m.userCount.Add(int64(len(envelopes)))
+	m.unstashed = append(envelopes, m.unstashed...)
+}
+
+// Drops oldest queued user messages while bounded mailbox holds more than capacity.
+// Priority mailbox drops messages with lowest priority, newest of them first
+func (m *Mailbox) dropOldest() {
+	if m.capacity <= 0 || m.policy != OverflowDropOldest {
+		return
+	}
+	for m.userCount.Load() > int64(m.capacity) {
+		dropped, ok := m.popDropped()
+		if !ok {
+			return
+		}
+		m.overflowCount.Add(1)
+		m.release()
+		m.deadLetters.publishEnvelope(dropped, DeadLetterMailboxFull)
+	}
+}
+
+// Number of user messages dropped or rejected because mailbox was full
+func (m *Mailbox) OverflowCount() int64 {
+	return m.overflowCount.Load()
+}
+
+// Number of user messages waiting in mailbox
+func (m *Mailbox) Len() int64 {
+	return m.userCount.Load()
+}
+
+// Marks mailbox as deleted, pending user messages are sent to dead letters
 func (m *Mailbox) delete() {
-	close(m.actorChan)
-	clear(m.queue)
+	if !m.deleted.CompareAndSwap(false, true) {
+		return
+	}
+	close(m.done)
+	m.drain()
+}
+
+// Empties deleted mailbox, must be called by processing goroutine
+func (m *Mailbox) drain() {
+	for {
+		envelope, ok := m.popUser()
+		if !ok {
+			break
+		}
+		m.release()
+		m.deadLetters.publishEnvelope(envelope, DeadLetterActorStopped)
+	}
+	for {
+		if _, ok := m.systemQueue.pop(); !ok {
+			break
+		}
+		m.systemCount.Add(-1)
+	}
 }
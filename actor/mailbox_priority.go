@@ -0,0 +1,49 @@
+package actor
+
+// MailboxPriority decides order in which mailbox passes user messages to actor,
+// system messages are always passed before user messages
+type MailboxPriority int
+
+const (
+	MailboxFIFO         MailboxPriority = iota // User messages are passed in order they arrived
+	MailboxUserPriority                        // User messages are ordered by Priority, higher first
+)
+
+// PriorityMessage is user message with priority, used by mailbox with MailboxUserPriority.
+// Messages that do not implement it have priority 0, messages with same priority keep arrival order
+type PriorityMessage interface {
+	Priority() int
+}
+
+// Creates mailbox that orders user messages by priority
+func NewPriorityMailbox(deadLetters *DeadLetters, priority MailboxPriority) *Mailbox {
+	m := NewMailbox(deadLetters)
+	m.setPriority(priority)
+	return m
+}
+
+func (m *Mailbox) setPriority(priority MailboxPriority) {
+	if priority == MailboxUserPriority {
+		m.userQueue = newPriorityQueue()
+	}
+}
+
+// Creates mailbox configured by actor props
+func newMailboxFromProps(deadLetters *DeadLetters, prop *ActorProps) *Mailbox {
+	var m *Mailbox
+	if prop.MailboxCapacity() > 0 {
+		m = NewBoundedMailbox(deadLetters, prop.MailboxCapacity(), prop.OverflowPolicy())
+	} else {
+		m = NewMailbox(deadLetters)
+	}
+	m.setPriority(prop.MailboxPriority())
+	m.SetDispatcher(prop.Dispatcher())
+	return m
+}
+
+func messagePriority(envelope Envelope) int {
+	if msg, ok := envelope.Message.(PriorityMessage); ok {
+		return msg.Priority()
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package actor
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+)
+
+// mailboxQueue is queue with many producers and single consumer, pop is called only by consumer
+type mailboxQueue interface {
+	push(envelope Envelope)
+	pop() (Envelope, bool)
+}
+
+type mpscNode struct {
+	next     atomic.Pointer[mpscNode]
+	envelope Envelope
+}
+
+// mpscQueue is lock-free intrusive queue, producers only swap head and consumer owns tail
+type mpscQueue struct {
+	head atomic.Pointer[mpscNode] // Last pushed node
+	tail *mpscNode                // Node before first envelope in queue
+}
+
+func newMPSCQueue() *mpscQueue {
+	stub := &mpscNode{}
+	q := &mpscQueue{tail: stub}
+	q.head.Store(stub)
+	return q
+}
+
+func (q *mpscQueue) push(envelope Envelope) {
+	node := &mpscNode{envelope: envelope}
+	prev := q.head.Swap(node)
+	// node is visible to consumer only after it is linked
+	prev.next.Store(node)
+}
+
+func (q *mpscQueue) pop() (Envelope, bool) {
+	next := q.tail.next.Load()
+	if next == nil {
+		return Envelope{}, false
+	}
+	q.tail = next
+	envelope := next.envelope
+	next.envelope = Envelope{}
+	return envelope, true
+}
+
+type priorityItem struct {
+	envelope Envelope
+	priority int
+	seq      uint64 // Keeps arrival order of messages with same priority
+}
+
+type priorityItems []priorityItem
+
+func (items priorityItems) Len() int { return len(items) }
+
+func (items priorityItems) Less(i, j int) bool {
+	if items[i].priority != items[j].priority {
+		return items[i].priority > items[j].priority
+	}
+	return items[i].seq < items[j].seq
+}
+
+func (items priorityItems) Swap(i, j int) { items[i], items[j] = items[j], items[i] }
+
+func (items *priorityItems) Push(x any) { *items = append(*items, x.(priorityItem)) }
+
+func (items *priorityItems) Pop() any {
+	old := *items
+	item := old[len(old)-1]
+	*items = old[:len(old)-1]
+	return item
+}
+
+// priorityQueue orders user messages by priority, higher first
+type priorityQueue struct {
+	items priorityItems
+	seq   uint64
+	mu    sync.Mutex
+}
+
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{}
+}
+
+func (q *priorityQueue) push(envelope Envelope) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.seq++
+	heap.Push(&q.items, priorityItem{envelope: envelope, priority: messagePriority(envelope), seq: q.seq})
+}
+
+func (q *priorityQueue) pop() (Envelope, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return Envelope{}, false
+	}
+	return heap.Pop(&q.items).(priorityItem).envelope, true
+}
+
+// Removes message that would be popped last, lowest priority and newest among them
+func (q *priorityQueue) popLowest() (Envelope, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return Envelope{}, false
+	}
+	lowest := len(q.items) / 2 // last item is always leaf
+	for i := lowest + 1; i < len(q.items); i++ {
+		if q.items.Less(lowest, i) {
+			lowest = i
+		}
+	}
+	return heap.Remove(&q.items, lowest).(priorityItem).envelope, true
+}
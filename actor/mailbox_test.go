@@ -0,0 +1,251 @@
+package actor
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type priorityMessage int
+
+func (m priorityMessage) Priority() int {
+	return int(m)
+}
+
+// recordingInvoker keeps system and user messages in order they were passed to it
+type recordingInvoker struct {
+	messages []interface{}
+	user     []interface{}
+	mu       sync.Mutex
+}
+
+func (i *recordingInvoker) InvokeSystemMessage(envelope Envelope) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages = append(i.messages, envelope.Message)
+}
+
+func (i *recordingInvoker) InvokeUserMessage(envelope Envelope) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages = append(i.messages, envelope.Message)
+	i.user = append(i.user, envelope.Message)
+}
+
+func (i *recordingInvoker) HandlePanic(reason interface{}) {}
+
+func (i *recordingInvoker) allMessages() []interface{} {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]interface{}(nil), i.messages...)
+}
+
+func (i *recordingInvoker) userMessages() []interface{} {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]interface{}(nil), i.user...)
+}
+
+// manualDispatcher keeps scheduled mailboxes until test runs them
+type manualDispatcher struct {
+	tasks []func()
+}
+
+func (d *manualDispatcher) Schedule(fn func()) {
+	d.tasks = append(d.tasks, fn)
+}
+
+func (d *manualDispatcher) Throughput() int {
+	return defaultThroughput
+}
+
+func (d *manualDispatcher) runAll() {
+	for len(d.tasks) > 0 {
+		task := d.tasks[0]
+		d.tasks = d.tasks[1:]
+		task()
+	}
+}
+
+func newTestMailbox(t *testing.T, m *Mailbox, dispatcher Dispatcher) (*recordingInvoker, PID) {
+	t.Helper()
+	invoker := &recordingInvoker{}
+	m.RegisterInvoker(invoker)
+	m.SetDispatcher(dispatcher)
+	pid, err := NewPID()
+	if err != nil {
+		t.Fatalf("failed to create pid: %v", err)
+	}
+	return invoker, pid
+}
+
+func TestPriorityMailboxDropsLowestPriority(t *testing.T) {
+	deadLetters := NewActorSystem().DeadLetters()
+	m := NewBoundedMailbox(deadLetters, 2, OverflowDropOldest)
+	m.setPriority(MailboxUserPriority)
+	dispatcher := &manualDispatcher{}
+	invoker, pid := newTestMailbox(t, m, dispatcher)
+
+	for _, priority := range []int{5, 1, 3, 1} {
+		m.Send(NewEnvelope(priorityMessage(priority), pid))
+	}
+	dispatcher.runAll()
+
+	received := invoker.userMessages()
+	if len(received) != 2 || received[0] != priorityMessage(5) || received[1] != priorityMessage(3) {
+		t.Fatalf("received %v, expected highest priorities 5 and 3", received)
+	}
+	if count := deadLetters.CountByReason(DeadLetterMailboxFull); count != 2 {
+		t.Fatalf("%d messages dropped, expected 2", count)
+	}
+}
+
+type producerMessage struct {
+	producer int
+	seq      int
+}
+
+func TestMPSCQueueConcurrentProducers(t *testing.T) {
+	const producers = 8
+	const perProducer = 10000
+	q := newMPSCQueue()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.push(Envelope{Message: producerMessage{producer: p, seq: i}})
+			}
+		}(p)
+	}
+
+	next := make([]int, producers)
+	for received := 0; received < producers*perProducer; {
+		envelope, ok := q.pop()
+		if !ok {
+			continue
+		}
+		msg := envelope.Message.(producerMessage)
+		if msg.seq != next[msg.producer] {
+			t.Fatalf("producer %d: received %d, expected %d", msg.producer, msg.seq, next[msg.producer])
+		}
+		next[msg.producer]++
+		received++
+	}
+	wg.Wait()
+	if _, ok := q.pop(); ok {
+		t.Fatal("queue has more envelopes than were pushed")
+	}
+}
+
+// countingInvoker signals done once expected number of user messages was processed
+type countingInvoker struct {
+	expected int64
+	count    int64
+	done     chan struct{}
+	mu       sync.Mutex
+}
+
+func (i *countingInvoker) InvokeSystemMessage(envelope Envelope) {}
+
+func (i *countingInvoker) InvokeUserMessage(envelope Envelope) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.count++
+	if i.count == i.expected {
+		close(i.done)
+	}
+}
+
+func (i *countingInvoker) HandlePanic(reason interface{}) {}
+
+func TestMailboxHandoffLosesNoMessages(t *testing.T) {
+	const senders = 8
+	const perSender = 5000
+	for name, dispatcher := range map[string]Dispatcher{
+		"goroutine":   NewDefaultDispatcher(1),
+		"worker pool": NewWorkerPoolDispatcher(2, 7),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if stopper, ok := dispatcher.(interface{ Stop() }); ok {
+				defer stopper.Stop()
+			}
+			m := NewMailbox(NewActorSystem().DeadLetters())
+			invoker := &countingInvoker{expected: senders * perSender, done: make(chan struct{})}
+			m.RegisterInvoker(invoker)
+			m.SetDispatcher(dispatcher)
+			pid, _ := NewPID()
+
+			for s := 0; s < senders; s++ {
+				go func() {
+					for i := 0; i < perSender; i++ {
+						m.Send(NewEnvelope(i, pid))
+						if i%100 == 0 {
+							// let mailbox become idle between bursts
+							time.Sleep(time.Microsecond)
+						}
+					}
+				}()
+			}
+			select {
+			case <-invoker.done:
+			case <-time.After(10 * time.Second):
+				invoker.mu.Lock()
+				defer invoker.mu.Unlock()
+				t.Fatalf("processed %d of %d messages", invoker.count, invoker.expected)
+			}
+			if m.Len() != 0 {
+				t.Fatalf("mailbox reports %d pending messages", m.Len())
+			}
+		})
+	}
+}
+
+func TestMailboxSystemMessagesFirst(t *testing.T) {
+	m := NewMailbox(NewActorSystem().DeadLetters())
+	dispatcher := &manualDispatcher{}
+	invoker, pid := newTestMailbox(t, m, dispatcher)
+
+	m.Send(NewEnvelope("first", pid))
+	m.Send(NewEnvelope("second", pid))
+	m.Send(NewEnvelope(SystemMessage{Type: SystemMessageStart}, pid))
+	dispatcher.runAll()
+
+	received := invoker.allMessages()
+	if len(received) != 3 {
+		t.Fatalf("received %d messages, expected 3", len(received))
+	}
+	if _, ok := received[0].(SystemMessage); !ok {
+		t.Fatalf("received %v first, expected system message", received[0])
+	}
+	if received[1] != "first" || received[2] != "second" {
+		t.Fatalf("received user messages %v, expected arrival order", received[1:])
+	}
+}
+
+func TestMailboxDeleteDrainsUserMessages(t *testing.T) {
+	deadLetters := NewActorSystem().DeadLetters()
+	m := NewMailbox(deadLetters)
+	dispatcher := &manualDispatcher{}
+	invoker, pid := newTestMailbox(t, m, dispatcher)
+
+	m.Send(NewEnvelope("first", pid))
+	m.Send(NewEnvelope("second", pid))
+	m.Send(NewEnvelope(SystemMessage{Type: DeleteMailbox}, pid))
+	dispatcher.runAll()
+	m.Send(NewEnvelope("after delete", pid))
+	m.Send(NewEnvelope(SystemMessage{Type: SystemMessageStart}, pid))
+	dispatcher.runAll()
+
+	if received := invoker.allMessages(); len(received) != 0 {
+		t.Fatalf("deleted mailbox passed %v to invoker", received)
+	}
+	if count := deadLetters.CountByReason(DeadLetterActorStopped); count != 3 {
+		t.Fatalf("%d dead letters, expected 3", count)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("deleted mailbox reports %d pending messages", m.Len())
+	}
+}
@@ -2,8 +2,12 @@ package actor
 
 import "github.com/google/uuid"
 
+// PID identifies actor, Path is hierarchical name of actor such as /user/orders/order-42.
+// Address is address of node actor lives on, it is empty for actors of local actor system
 type PID struct {
-	ID uuid.UUID
+	ID      uuid.UUID
+	Path    string
+	Address string
 }
 
 func NewPID() (PID, error) {
@@ -17,3 +21,18 @@ func NewPID() (PID, error) {
 func (pid *PID) Equal(other *PID) bool {
 	return pid != nil && other != nil && pid.ID == other.ID
 }
+
+// String returns address and path of actor, ID is used if actor has no path
+func (pid PID) String() string {
+	name := pid.Path
+	if name == "" {
+		name = pid.ID.String()
+		if pid.Address != "" {
+			name = "/" + name
+		}
+	}
+	if pid.Address != "" {
+		return pid.Address + name
+	}
+	return name
+}
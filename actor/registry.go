@@ -1,38 +1,105 @@
 package actor
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNameExists  = errors.New("actor name already exists")
+	ErrInvalidName = errors.New("invalid actor name")
 )
 
+// Path under which actors without parent are spawned
+const userPath = "/user"
+
+// Process receives envelopes sent to PID, it is either actor mailbox or channel of future or remote proxy
+type Process interface {
+	Send(envelope Envelope) error
+}
+
+// chanProcess delivers envelopes to channel, envelopes that do not fit are dead letters
+type chanProcess struct {
+	ch          chan Envelope
+	deadLetters *DeadLetters
+}
+
+func (p chanProcess) Send(envelope Envelope) error {
+	select {
+	case p.ch <- envelope:
+	default:
+		p.deadLetters.publishEnvelope(envelope, DeadLetterFutureCompleted)
+	}
+	return nil
+}
+
 type Registry struct {
-	mapping map[PID]chan Envelope // Stores mailbox channels
+	mapping map[uuid.UUID]Process // Stores mailboxes by PID ID
+	paths   map[string]PID        // Stores PIDs by actor path
 	mu      sync.RWMutex
 }
 
 func NewRegistry() *Registry {
-	return &Registry{mapping: make(map[PID]chan Envelope)}
+	return &Registry{mapping: make(map[uuid.UUID]Process), paths: make(map[string]PID)}
 }
 
-func (r *Registry) Add(pid PID, ch chan Envelope) error {
+// Adds process, error is returned if PID has path that is already taken
+func (r *Registry) Add(pid PID, process Process) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.mapping[pid] = ch
+	if pid.Path != "" {
+		if _, exists := r.paths[pid.Path]; exists {
+			return fmt.Errorf("%w: %s", ErrNameExists, pid.Path)
+		}
+		r.paths[pid.Path] = pid
+	}
+	r.mapping[pid.ID] = process
 	return nil
 }
 
-func (r *Registry) Find(pid PID) chan Envelope {
+func (r *Registry) Find(pid PID) Process {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mapping[pid.ID]
+}
+
+// Lookup returns PID of actor with path
+func (r *Registry) Lookup(path string) (PID, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.mapping[pid]
+	pid, exists := r.paths[path]
+	return pid, exists
 }
 
 func (r *Registry) Remove(pid PID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if _, exists := r.mapping[pid]; exists {
-		delete(r.mapping, pid)
+	if _, exists := r.mapping[pid.ID]; exists {
+		delete(r.mapping, pid.ID)
+		if named, exists := r.paths[pid.Path]; exists && named.ID == pid.ID {
+			delete(r.paths, pid.Path)
+		}
 		return nil
 	}
 	return fmt.Errorf("PID not found: %v", pid)
 }
+
+// Names starting with $ are reserved for actors spawned without name
+func validateName(name string) error {
+	if name == "" || strings.Contains(name, "/") || strings.HasPrefix(name, "$") {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Returns path of child with name, actors without parent are placed under /user
+func childPath(parent *PID, name string) string {
+	if parent == nil || parent.Path == "" {
+		return userPath + "/" + name
+	}
+	return parent.Path + "/" + name
+}
@@ -0,0 +1,109 @@
+package actor
+
+import (
+	"sync"
+	"time"
+)
+
+// RestartStatistics holds failure times of a single actor
+type RestartStatistics struct {
+	failureTimes []time.Time
+}
+
+func NewRestartStatistics() *RestartStatistics {
+	return &RestartStatistics{
+		failureTimes: make([]time.Time, 0),
+	}
+}
+
+func (rs *RestartStatistics) Fail() {
+	rs.failureTimes = append(rs.failureTimes, time.Now())
+}
+
+func (rs *RestartStatistics) Reset() {
+	rs.failureTimes = rs.failureTimes[:0]
+}
+
+func (rs *RestartStatistics) FailureCount() int {
+	return len(rs.failureTimes)
+}
+
+// NumberOfFailures returns number of failures within duration, zero duration counts all failures
+func (rs *RestartStatistics) NumberOfFailures(within time.Duration) int {
+	if within <= 0 {
+		return len(rs.failureTimes)
+	}
+
+	count := 0
+	now := time.Now()
+	for _, failureTime := range rs.failureTimes {
+		if now.Sub(failureTime) <= within {
+			count++
+		}
+	}
+	return count
+}
+
+// Removes failures older than duration so statistics don't grow forever
+func (rs *RestartStatistics) trim(within time.Duration) {
+	if within <= 0 {
+		return
+	}
+
+	now := time.Now()
+	index := 0
+	for index < len(rs.failureTimes) && now.Sub(rs.failureTimes[index]) > within {
+		index++
+	}
+	rs.failureTimes = rs.failureTimes[index:]
+}
+
+// restartLimit tracks restart statistics per child, allowing at most maxRetries restarts within duration
+type restartLimit struct {
+	maxRetries     int
+	withinDuration time.Duration
+	statistics     map[PID]*RestartStatistics
+	mu             sync.Mutex
+}
+
+func newRestartLimit(maxRetries int, withinDuration time.Duration) *restartLimit {
+	return &restartLimit{
+		maxRetries:     maxRetries,
+		withinDuration: withinDuration,
+		statistics:     make(map[PID]*RestartStatistics),
+	}
+}
+
+// Records failure of child and returns if child can be restarted,
+// statistics of child are removed once restart budget is exhausted
+func (limit *restartLimit) requestRestart(child PID) bool {
+	limit.mu.Lock()
+	defer limit.mu.Unlock()
+
+	stats, exists := limit.statistics[child]
+	if !exists {
+		stats = NewRestartStatistics()
+		limit.statistics[child] = stats
+	}
+
+	stats.trim(limit.withinDuration)
+	stats.Fail()
+
+	if stats.NumberOfFailures(limit.withinDuration) > limit.maxRetries {
+		delete(limit.statistics, child)
+		return false
+	}
+	return true
+}
+
+// Returns copy of restart statistics of child
+func (limit *restartLimit) statisticsOf(child PID) RestartStatistics {
+	limit.mu.Lock()
+	defer limit.mu.Unlock()
+
+	stats, exists := limit.statistics[child]
+	if !exists {
+		return *NewRestartStatistics()
+	}
+	return RestartStatistics{failureTimes: append([]time.Time(nil), stats.failureTimes...)}
+}
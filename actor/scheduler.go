@@ -0,0 +1,186 @@
+package actor
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CancelFunc cancels scheduled message, it is safe to call it more than once
+type CancelFunc func()
+
+// ReceiveTimeout is received when actor gets no messages for duration set with SetReceiveTimeout
+type ReceiveTimeout struct{}
+
+// SendAfter sends message to receiver once delay passes
+func (system *ActorSystem) SendAfter(delay time.Duration, message interface{}, receiver PID) CancelFunc {
+	return sendAfter(delay, func() {
+		system.Send(NewEnvelope(message, receiver))
+	})
+}
+
+// SendRepeatedly sends message to receiver after initial delay and then on every interval until cancelled
+func (system *ActorSystem) SendRepeatedly(initialDelay time.Duration, interval time.Duration, message interface{}, receiver PID) CancelFunc {
+	return sendRepeatedly(initialDelay, interval, func() {
+		system.Send(NewEnvelope(message, receiver))
+	})
+}
+
+func sendAfter(delay time.Duration, send func()) CancelFunc {
+	timer := time.AfterFunc(delay, send)
+	return func() {
+		timer.Stop()
+	}
+}
+
+func sendRepeatedly(initialDelay time.Duration, interval time.Duration, send func()) CancelFunc {
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		timer := time.NewTimer(initialDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			send()
+		case <-done:
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				send()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+// actorTimers holds timers owned by actor, they are cancelled when actor stops or restarts
+type actorTimers struct {
+	system         *ActorSystem
+	owner          PID
+	cancels        map[int]CancelFunc // Created when first timer is scheduled
+	nextID         int
+	receiveTimeout time.Duration
+	receiveTimer   *time.Timer
+	hasTimeout     atomic.Bool // Avoids locking for every message when receive timeout is not set
+	stopped        bool
+	mu             sync.Mutex
+}
+
+func newActorTimers(system *ActorSystem, owner PID) *actorTimers {
+	return &actorTimers{system: system, owner: owner}
+}
+
+func (t *actorTimers) schedule(start func(send func()) CancelFunc, message interface{}, receiver PID, repeated bool) CancelFunc {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return func() {}
+	}
+
+	t.nextID++
+	id := t.nextID
+	send := func() {
+		if !repeated {
+			t.remove(id)
+		}
+		t.system.Send(NewEnvelopeWithSender(message, receiver, t.owner))
+	}
+
+	cancel := start(send)
+	if t.cancels == nil {
+		t.cancels = make(map[int]CancelFunc)
+	}
+	t.cancels[id] = cancel
+
+	return func() {
+		cancel()
+		t.remove(id)
+	}
+}
+
+func (t *actorTimers) sendAfter(delay time.Duration, message interface{}, receiver PID) CancelFunc {
+	return t.schedule(func(send func()) CancelFunc {
+		return sendAfter(delay, send)
+	}, message, receiver, false)
+}
+
+func (t *actorTimers) sendRepeatedly(initialDelay time.Duration, interval time.Duration, message interface{}, receiver PID) CancelFunc {
+	return t.schedule(func(send func()) CancelFunc {
+		return sendRepeatedly(initialDelay, interval, send)
+	}, message, receiver, true)
+}
+
+func (t *actorTimers) remove(id int) {
+	t.mu.Lock()
+	delete(t.cancels, id)
+	t.mu.Unlock()
+}
+
+func (t *actorTimers) setReceiveTimeout(timeout time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
+
+	t.receiveTimeout = timeout
+	if t.receiveTimer != nil {
+		t.receiveTimer.Stop()
+		t.receiveTimer = nil
+	}
+	t.hasTimeout.Store(timeout > 0)
+	if timeout <= 0 {
+		return
+	}
+
+	owner := t.owner
+	t.receiveTimer = time.AfterFunc(timeout, func() {
+		t.system.Send(NewEnvelope(ReceiveTimeout{}, owner))
+	})
+}
+
+// Restarts receive timeout, called for every message actor receives
+func (t *actorTimers) messageReceived() {
+	if !t.hasTimeout.Load() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.receiveTimer != nil {
+		t.receiveTimer.Reset(t.receiveTimeout)
+	}
+}
+
+func (t *actorTimers) cancelAll() {
+	t.mu.Lock()
+	t.stopped = true
+	t.hasTimeout.Store(false)
+	cancels := t.cancels
+	t.cancels = nil
+	if t.receiveTimer != nil {
+		t.receiveTimer.Stop()
+		t.receiveTimer = nil
+	}
+	t.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
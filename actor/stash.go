@@ -0,0 +1,50 @@
+package actor
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrStashFull = errors.New("stash is full")
+
+const defaultStashCapacity = 1000
+
+// stash holds envelopes actor deferred for later processing, it belongs to actor context
+type stash struct {
+	envelopes []Envelope
+	capacity  int
+	mu        sync.Mutex
+}
+
+func (s *stash) push(envelope Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.envelopes) >= s.capacity {
+		return ErrStashFull
+	}
+	s.envelopes = append(s.envelopes, envelope)
+	return nil
+}
+
+// Removes and returns all stashed envelopes
+func (s *stash) takeAll() []Envelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	envelopes := s.envelopes
+	s.envelopes = nil
+	return envelopes
+}
+
+// Stash defers current message, it is received again after UnstashAll
+func (ctx *ActorContext) Stash() error {
+	return ctx.stash.push(ctx.envelope)
+}
+
+// UnstashAll returns stashed messages to mailbox, they are received before messages that are already in mailbox
+func (ctx *ActorContext) UnstashAll() {
+	envelopes := ctx.stash.takeAll()
+	if len(envelopes) == 0 {
+		return
+	}
+	ctx.actorSystem.SendSystemMessage(ctx.self, SystemMessage{Type: UnstashMailbox, Extras: envelopes})
+}
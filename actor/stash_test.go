@@ -0,0 +1,40 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+// stashingActor stashes string messages and stops itself on stop
+type stashingActor struct{}
+
+func (a *stashingActor) Receive(ctx ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case string:
+		if msg == "stop" {
+			ctx.Stop()
+			return
+		}
+		ctx.Stash()
+	}
+}
+
+func TestStashedMessagesAreDeadLettersOnStop(t *testing.T) {
+	system := NewActorSystem()
+	pid, err := system.SpawnActor(&stashingActor{})
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+
+	system.Send(NewEnvelope("first", pid))
+	system.Send(NewEnvelope("second", pid))
+	system.Send(NewEnvelope("stop", pid))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for system.DeadLetters().CountByReason(DeadLetterActorStopped) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d stashed messages published as dead letters, expected 2", system.DeadLetters().CountByReason(DeadLetterActorStopped))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -1,47 +1,97 @@
 package actor
 
-type restartAllStrategy struct{}
+import "time"
+
+type restartAllStrategy struct {
+	limit    *restartLimit
+	fallback FailureStrategy
+}
 
 type stopAllStrategy struct{}
 
+type allForOneStrategy struct {
+	decider  Decider
+	restart  FailureStrategy
+	stop     FailureStrategy
+	resume   FailureStrategy
+	escalate FailureStrategy
+}
+
 func NewRestartAllStrategy() *restartAllStrategy {
 	return &restartAllStrategy{}
 }
 
+// Restarts all actors at most maxRetries times within duration, zero duration counts all failures.
+// Once restart budget is exhausted failure is handled by fallback strategy, stop all strategy if fallback is nil
+func NewRestartAllStrategyWithLimit(maxRetries int, withinDuration time.Duration, fallback FailureStrategy) *restartAllStrategy {
+	if fallback == nil {
+		fallback = NewStopAllStrategy()
+	}
+	return &restartAllStrategy{
+		limit:    newRestartLimit(maxRetries, withinDuration),
+		fallback: fallback,
+	}
+}
+
 func NewStopAllStrategy() *stopAllStrategy {
 	return &stopAllStrategy{}
 }
 
+// Handles failure with directive chosen by decider, restart and stop apply to all children of supervisor.
+// DefaultDecider is used if decider is nil
+func NewAllForOneStrategy(decider Decider) *allForOneStrategy {
+	return newAllForOneStrategy(decider, NewRestartAllStrategy())
+}
+
+// Same as NewAllForOneStrategy, restart is limited to maxRetries within duration, after that all actors are stopped
+func NewAllForOneStrategyWithLimit(maxRetries int, withinDuration time.Duration, decider Decider) *allForOneStrategy {
+	return newAllForOneStrategy(decider, NewRestartAllStrategyWithLimit(maxRetries, withinDuration, nil))
+}
+
+func newAllForOneStrategy(decider Decider, restart FailureStrategy) *allForOneStrategy {
+	if decider == nil {
+		decider = DefaultDecider
+	}
+	return &allForOneStrategy{
+		decider:  decider,
+		restart:  restart,
+		stop:     NewStopAllStrategy(),
+		resume:   NewResumeOneStrategy(),
+		escalate: NewEscalateStrategy(),
+	}
+}
+
 func (strategy *restartAllStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
 
+	if strategy.limit != nil && !strategy.limit.requestRestart(failure.Who) {
+		strategy.fallback.HandleFailure(actorSystem, supervisor, failure)
+		return
+	}
+
 	// fmt.Println("Restarting all actors")
 
 	children := supervisor.Children()
 
-	switch failure.Reason.(type) {
-	case NotPanic:
-		failureChildren := failure.ActorContext.Children()
-		for _, child := range failureChildren {
-			actorSystem.Stop(*child)
-		}
-		for _, child := range children {
-			actorSystem.Restart(*child)
-		}
-	// default case is for panic
-	default:
-		actorSystem.RespawnActor(failure.Actor, failure.Who, failure.ActorChan, *failure.ActorContext.Props())
-		failureChildren := failure.ActorContext.Children()
-		for _, child := range failureChildren {
-			actorSystem.Stop(*child)
-		}
-		for _, child := range children {
-			if *child != failure.Who {
-				actorSystem.Restart(*child)
-			}
+	actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: SystemMessageRestart, Extras: failure.Reason})
+	failureChildren := failure.ActorContext.Children()
+	for _, child := range failureChildren {
+		actorSystem.Stop(*child)
+	}
+	for _, child := range children {
+		if *child != failure.Who {
+			actorSystem.SendSystemMessage(*child, SystemMessage{Type: SystemMessageRestart, Extras: failure.Reason})
 		}
 	}
 }
 
+// RestartStatistics returns restart statistics of child, empty if strategy has no restart limit
+func (strategy *restartAllStrategy) RestartStatistics(child PID) RestartStatistics {
+	if strategy.limit == nil {
+		return *NewRestartStatistics()
+	}
+	return strategy.limit.statisticsOf(child)
+}
+
 func (strategy *stopAllStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
 
 	// fmt.Println("Stopping all actors")
@@ -52,21 +102,35 @@ func (strategy *stopAllStrategy) HandleFailure(actorSystem *ActorSystem, supervi
 	case NotPanic:
 		for _, child := range children {
 			supervisor.RemoveChild(*child)
-			actorSystem.Send(NewEnvelope(SystemMessage{Type: SystemMessageStop}, *child))
+			actorSystem.Send(NewEnvelope(SystemMessage{Type: SystemMessageStop, Extras: failure.Reason}, *child))
 		}
 	// default case is for panic
 	default:
-		actorSystem.RemoveActor(failure.Who, SystemMessage{Type: DeleteMailbox})
+		// failed actor stops itself and its children, so it receives Stopping and Stopped
+		actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: SystemMessageStop, Extras: failure.Reason})
 		supervisor.RemoveChild(failure.Who)
-		failureChildren := failure.ActorContext.Children()
-		for _, child := range failureChildren {
-			actorSystem.Stop(*child)
-		}
 		for _, child := range children {
 			if *child != failure.Who {
 				supervisor.RemoveChild(*child)
-				actorSystem.Send(NewEnvelope(SystemMessage{Type: SystemMessageStop}, *child))
+				actorSystem.Send(NewEnvelope(SystemMessage{Type: SystemMessageStop, Extras: failure.Reason}, *child))
 			}
 		}
 	}
 }
+
+func (strategy *allForOneStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
+
+	directive := decide(strategy.decider, failure)
+	actorSystem.eventStream.Publish(SupervisorDirective{Who: failure.Who, Reason: failure.Reason, Directive: directive})
+
+	switch directive {
+	case ResumeDirective:
+		strategy.resume.HandleFailure(actorSystem, supervisor, failure)
+	case RestartDirective:
+		strategy.restart.HandleFailure(actorSystem, supervisor, failure)
+	case StopDirective:
+		strategy.stop.HandleFailure(actorSystem, supervisor, failure)
+	case EscalateDirective:
+		strategy.escalate.HandleFailure(actorSystem, supervisor, failure)
+	}
+}
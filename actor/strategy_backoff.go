@@ -0,0 +1,98 @@
+package actor
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type exponentialBackoffStrategy struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	backoffWindow  time.Duration
+	statistics     map[PID]*RestartStatistics
+	mu             sync.Mutex
+}
+
+// Restarts failed actor after delay that doubles with every failure within backoff window,
+// starting from initial backoff and capped at max backoff, which should not be smaller than initial backoff.
+// Delay is jittered between half and full value.
+// Failure count is reset once actor does not fail for the duration of backoff window,
+// zero backoff window keeps counting failures for as long as strategy is used
+func NewExponentialBackoffStrategy(initialBackoff time.Duration, maxBackoff time.Duration, backoffWindow time.Duration) *exponentialBackoffStrategy {
+	return &exponentialBackoffStrategy{
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		backoffWindow:  backoffWindow,
+		statistics:     make(map[PID]*RestartStatistics),
+	}
+}
+
+func (strategy *exponentialBackoffStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
+	delay := strategy.nextBackoff(failure.Who)
+	time.AfterFunc(delay, func() {
+		restartOne(actorSystem, failure)
+	})
+	if strategy.backoffWindow > 0 {
+		time.AfterFunc(delay+strategy.backoffWindow, func() {
+			strategy.forget(failure.Who)
+		})
+	}
+}
+
+// Removes statistics of child that did not fail within backoff window
+func (strategy *exponentialBackoffStrategy) forget(child PID) {
+	strategy.mu.Lock()
+	defer strategy.mu.Unlock()
+
+	stats, exists := strategy.statistics[child]
+	if exists && stats.NumberOfFailures(strategy.backoffWindow) == 0 {
+		delete(strategy.statistics, child)
+	}
+}
+
+// Records failure and returns jittered delay before restart
+func (strategy *exponentialBackoffStrategy) nextBackoff(child PID) time.Duration {
+	strategy.mu.Lock()
+	defer strategy.mu.Unlock()
+
+	stats, exists := strategy.statistics[child]
+	if !exists {
+		stats = NewRestartStatistics()
+		strategy.statistics[child] = stats
+	}
+
+	if stats.NumberOfFailures(strategy.backoffWindow) == 0 {
+		stats.Reset()
+	}
+	stats.Fail()
+
+	backoff := strategy.initialBackoff
+	for i := 1; i < stats.FailureCount(); i++ {
+		if backoff >= strategy.maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if backoff > strategy.maxBackoff {
+		backoff = strategy.maxBackoff
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// RestartStatistics returns restart statistics of child
+func (strategy *exponentialBackoffStrategy) RestartStatistics(child PID) RestartStatistics {
+	strategy.mu.Lock()
+	defer strategy.mu.Unlock()
+
+	stats, exists := strategy.statistics[child]
+	if !exists {
+		return *NewRestartStatistics()
+	}
+	return RestartStatistics{failureTimes: append([]time.Time(nil), stats.failureTimes...)}
+}
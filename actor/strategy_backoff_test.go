@@ -0,0 +1,31 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffStatisticsForgotten(t *testing.T) {
+	window := 20 * time.Millisecond
+	strategy := NewExponentialBackoffStrategy(time.Millisecond, time.Millisecond, window)
+	child, err := NewPID()
+	if err != nil {
+		t.Fatalf("failed to create pid: %v", err)
+	}
+
+	strategy.nextBackoff(child)
+	strategy.forget(child)
+	stats := strategy.RestartStatistics(child)
+	if count := stats.FailureCount(); count != 1 {
+		t.Fatalf("statistics removed within backoff window, failure count %d", count)
+	}
+
+	time.Sleep(2 * window)
+	strategy.forget(child)
+	strategy.mu.Lock()
+	_, exists := strategy.statistics[child]
+	strategy.mu.Unlock()
+	if exists {
+		t.Fatal("statistics kept after backoff window passed")
+	}
+}
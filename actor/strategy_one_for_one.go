@@ -1,6 +1,11 @@
 package actor
 
-type restartOneStrategy struct{}
+import "time"
+
+type restartOneStrategy struct {
+	limit    *restartLimit
+	fallback FailureStrategy
+}
 
 type stopOneStrategy struct{}
 
@@ -8,10 +13,30 @@ type escalateStrategy struct{}
 
 type resumeOneStrategy struct{}
 
+type oneForOneStrategy struct {
+	decider  Decider
+	restart  FailureStrategy
+	stop     FailureStrategy
+	resume   FailureStrategy
+	escalate FailureStrategy
+}
+
 func NewRestartOneStrategy() *restartOneStrategy {
 	return &restartOneStrategy{}
 }
 
+// Restarts failed actor at most maxRetries times within duration, zero duration counts all failures.
+// Once restart budget is exhausted failure is handled by fallback strategy, stop strategy if fallback is nil
+func NewRestartOneStrategyWithLimit(maxRetries int, withinDuration time.Duration, fallback FailureStrategy) *restartOneStrategy {
+	if fallback == nil {
+		fallback = NewStopOneStrategy()
+	}
+	return &restartOneStrategy{
+		limit:    newRestartLimit(maxRetries, withinDuration),
+		fallback: fallback,
+	}
+}
+
 func NewStopOneStrategy() *stopOneStrategy {
 	return &stopOneStrategy{}
 }
@@ -24,43 +49,64 @@ func NewResumeOneStrategy() *resumeOneStrategy {
 	return &resumeOneStrategy{}
 }
 
+// Handles failure of single actor with directive chosen by decider, DefaultDecider is used if decider is nil
+func NewOneForOneStrategy(decider Decider) *oneForOneStrategy {
+	return newOneForOneStrategy(decider, NewRestartOneStrategy())
+}
+
+// Same as NewOneForOneStrategy, restart is limited to maxRetries within duration, after that actor is stopped
+func NewOneForOneStrategyWithLimit(maxRetries int, withinDuration time.Duration, decider Decider) *oneForOneStrategy {
+	return newOneForOneStrategy(decider, NewRestartOneStrategyWithLimit(maxRetries, withinDuration, nil))
+}
+
+func newOneForOneStrategy(decider Decider, restart FailureStrategy) *oneForOneStrategy {
+	if decider == nil {
+		decider = DefaultDecider
+	}
+	return &oneForOneStrategy{
+		decider:  decider,
+		restart:  restart,
+		stop:     NewStopOneStrategy(),
+		resume:   NewResumeOneStrategy(),
+		escalate: NewEscalateStrategy(),
+	}
+}
+
 func (strategy *restartOneStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
 
+	if strategy.limit != nil && !strategy.limit.requestRestart(failure.Who) {
+		strategy.fallback.HandleFailure(actorSystem, supervisor, failure)
+		return
+	}
+
+	restartOne(actorSystem, failure)
+}
+
+// RestartStatistics returns restart statistics of child, empty if strategy has no restart limit
+func (strategy *restartOneStrategy) RestartStatistics(child PID) RestartStatistics {
+	if strategy.limit == nil {
+		return *NewRestartStatistics()
+	}
+	return strategy.limit.statisticsOf(child)
+}
+
+func restartOne(actorSystem *ActorSystem, failure Failure) {
+
 	// fmt.Println("Restarting actor")
-	switch failure.Reason.(type) {
-	case NotPanic:
-		children := failure.ActorContext.Children()
-		for _, child := range children {
-			actorSystem.Stop(*child)
-		}
-		actorSystem.Restart(failure.Who)
-	// default case is for panic
-	default:
-		children := failure.ActorContext.Children()
-		for _, child := range children {
-			actorSystem.Stop(*child)
-		}
-		actorSystem.RespawnActor(failure.Actor, failure.Who, failure.ActorChan, *failure.ActorContext.Props())
+	// mailbox of failed actor is suspended but still processes system messages, actor restarts itself
+	children := failure.ActorContext.Children()
+	for _, child := range children {
+		actorSystem.Stop(*child)
 	}
+	actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: SystemMessageRestart, Extras: failure.Reason})
 }
 
 func (strategy *stopOneStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
 
 	// fmt.Println("Stopping actor")
-	switch failure.Reason.(type) {
-	case NotPanic:
-		actorSystem.Stop(failure.Who)
-		supervisor.RemoveChild(failure.Who)
-	// default case is for panic
-	default:
-		actorSystem.RemoveActor(failure.Who, SystemMessage{Type: DeleteMailbox})
-		supervisor.RemoveChild(failure.Who)
-		children := failure.ActorContext.Children()
-		for _, child := range children {
-			supervisor.RemoveChild(*child)
-			actorSystem.Stop(*child)
-		}
-	}
+	// failed actor stops itself and its children, so it receives Stopping and Stopped
+	actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: SystemMessageStop, Extras: failure.Reason})
+	supervisor.RemoveChild(failure.Who)
 }
 
 func (strategy *escalateStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
@@ -71,7 +117,7 @@ func (strategy *escalateStrategy) HandleFailure(actorSystem *ActorSystem, superv
 	case NotPanic:
 		supervisor.EscalateFailure(failure)
 	default:
-		actorSystem.RemoveActor(failure.Who, SystemMessage{Type: DeleteMailbox})
+		actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: SystemMessageStop, Extras: failure.Reason})
 		supervisor.RemoveChild(failure.Who)
 		supervisor.EscalateFailure(failure)
 	}
@@ -80,9 +126,23 @@ func (strategy *escalateStrategy) HandleFailure(actorSystem *ActorSystem, superv
 func (strategy *resumeOneStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
 
 	// fmt.prinlnt("Resuming actor")
-	switch failure.Reason.(type) {
-	// default case, shouldn't be for panic
-	default:
-		actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: ResumeMailboxAll})
+	// message that caused failure is dropped, actor keeps its context
+	actorSystem.SendSystemMessage(failure.Who, SystemMessage{Type: ResumeMailboxAll})
+}
+
+func (strategy *oneForOneStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, failure Failure) {
+
+	directive := decide(strategy.decider, failure)
+	actorSystem.eventStream.Publish(SupervisorDirective{Who: failure.Who, Reason: failure.Reason, Directive: directive})
+
+	switch directive {
+	case ResumeDirective:
+		strategy.resume.HandleFailure(actorSystem, supervisor, failure)
+	case RestartDirective:
+		strategy.restart.HandleFailure(actorSystem, supervisor, failure)
+	case StopDirective:
+		strategy.stop.HandleFailure(actorSystem, supervisor, failure)
+	case EscalateDirective:
+		strategy.escalate.HandleFailure(actorSystem, supervisor, failure)
 	}
 }
@@ -15,3 +15,44 @@ var (
 	defaultSupervisionStrategy = NewRestartOneStrategy()
 	defaultRootStrategy        = NewRestartOneStrategy()
 )
+
+// Directive tells strategy how to handle failure
+type Directive int
+
+const (
+	ResumeDirective Directive = iota
+	RestartDirective
+	StopDirective
+	EscalateDirective
+)
+
+func (d Directive) String() string {
+	switch d {
+	case ResumeDirective:
+		return "Resume"
+	case RestartDirective:
+		return "Restart"
+	case StopDirective:
+		return "Stop"
+	case EscalateDirective:
+		return "Escalate"
+	default:
+		return "Unknown"
+	}
+}
+
+// Decider maps failure reason to directive, NotPanic failures are unwrapped before decider is called
+type Decider func(reason interface{}) Directive
+
+// DefaultDecider restarts actor on every failure
+func DefaultDecider(reason interface{}) Directive {
+	return RestartDirective
+}
+
+func decide(decider Decider, failure Failure) Directive {
+	reason := failure.Reason
+	if notPanic, ok := reason.(NotPanic); ok {
+		reason = notPanic.Reason
+	}
+	return decider(reason)
+}
@@ -0,0 +1,51 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+// lifecycleActor panics on string message and reports lifecycle messages
+type lifecycleActor struct {
+	lifecycle chan LifecycleMessage
+}
+
+func (a *lifecycleActor) Receive(ctx ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case Stopping, Stopped:
+		a.lifecycle <- msg.(LifecycleMessage)
+	case string:
+		panic(msg)
+	}
+}
+
+func TestStopStrategyStopsFailedActor(t *testing.T) {
+	strategies := map[string]FailureStrategy{
+		"stop one": NewStopOneStrategy(),
+		"stop all": NewStopAllStrategy(),
+	}
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			system := NewActorSystem()
+			props := NewActorProps(nil)
+			props.SetRootStrategy(strategy)
+			lifecycle := make(chan LifecycleMessage, 2)
+			pid, err := system.SpawnActor(&lifecycleActor{lifecycle: lifecycle}, *props)
+			if err != nil {
+				t.Fatalf("failed to spawn actor: %v", err)
+			}
+
+			system.Send(NewEnvelope("fail", pid))
+			for _, expected := range []LifecycleMessage{Stopping{}, Stopped{}} {
+				select {
+				case msg := <-lifecycle:
+					if msg != expected {
+						t.Fatalf("received %T, expected %T", msg, expected)
+					}
+				case <-time.After(2 * time.Second):
+					t.Fatalf("failed actor did not receive %T", expected)
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 	SuspendMailboxAll
 	ResumeMailboxAll
 	SystemMessageEscalateFailure
+	UnstashMailbox
 )
 
 type SystemMessage struct {
@@ -27,7 +28,6 @@ type Failure struct {
 	Reason       interface{}
 	Actor        Actor
 	ActorContext *ActorContext
-	ActorChan    chan Envelope
 }
 
 type NotPanic struct {
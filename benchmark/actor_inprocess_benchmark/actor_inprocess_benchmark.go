@@ -12,9 +12,8 @@ import (
 	"light-actor-go/actor"
 )
 
-type Msg struct {
-	Sender actor.PID
-}
+type Msg struct{}
+
 type Start struct {
 	Sender actor.PID
 }
@@ -30,9 +29,9 @@ type PingActor struct {
 type PongActor struct{}
 
 func (p *PongActor) Receive(ctx actor.ActorContext) {
-	switch msg := ctx.Message().(type) {
+	switch ctx.Message().(type) {
 	case *Msg:
-		ctx.Send(&Msg{Sender: *ctx.Self()}, msg.Sender)
+		ctx.Respond(&Msg{})
 	}
 }
 
@@ -41,9 +40,7 @@ func (p *PingActor) sendBatch(ctx actor.ActorContext, sender actor.PID) bool {
 		return false
 	}
 
-	msg := &Msg{
-		Sender: *ctx.Self(),
-	}
+	msg := &Msg{}
 
 	for i := 0; i < p.batchSize; i++ {
 		ctx.Send(msg, sender)
@@ -64,7 +61,7 @@ func (p *PingActor) Receive(ctx actor.ActorContext) {
 			return
 		}
 
-		if !p.sendBatch(ctx, msg.Sender) {
+		if !p.sendBatch(ctx, *ctx.Sender()) {
 			p.wgStop.Done()
 		}
 	}
@@ -118,9 +115,11 @@ func main() {
 		clientCount := runtime.NumCPU() * 2
 		clients := make([]actor.PID, clientCount)
 		echos := make([]actor.PID, clientCount)
+		props := actor.NewActorProps(nil)
+		props.SetDispatcher(actor.NewDefaultDispatcher(tp))
 		for i := 0; i < clientCount; i++ {
-			client, _ := system.SpawnActor(NewPingActor(&wg, messageCount, batchSize))
-			echo, _ := system.SpawnActor(&PongActor{})
+			client, _ := system.SpawnActor(NewPingActor(&wg, messageCount, batchSize), *props)
+			echo, _ := system.SpawnActor(&PongActor{}, *props)
 			clients[i] = client
 			echos[i] = echo
 			wg.Add(1)
@@ -1,6 +1,7 @@
 package remote_benchmark_test
 
 import (
+	"context"
 	"fmt"
 	"light-actor-go/actor"
 	"light-actor-go/remote"
@@ -16,7 +17,7 @@ type BenchmarkActor struct {
 
 func (a *BenchmarkActor) Receive(ctx actor.ActorContext) {
 	switch ctx.Message().(type) {
-	case actor.SystemMessage:
+	case actor.LifecycleMessage:
 		return
 	default:
 		a.count++
@@ -53,8 +54,10 @@ func BenchmarkRemoteActorTest(b *testing.B) {
 
 	system := actor.NewActorSystem()
 	remote1 := remote.NewRemote(*remote.NewRemoteConfig("127.0.0.1:" + port1), system)
-	remote1.Listen()
-	//defer remote1.Shutdown() // Ensure the listener is properly closed
+	if err := remote1.Start(); err != nil {
+		b.Fatalf("failed to start remote: %v", err)
+	}
+	defer remote1.Shutdown(context.Background()) // Ensure the listener is properly closed
 	time.Sleep(time.Second)
 	// Create and register the receiver actor
 	receiverActor := &BenchmarkActor{done: make(chan struct{})}
@@ -67,8 +70,10 @@ func BenchmarkRemoteActorTest(b *testing.B) {
 	// Create a remote actor system
 	remoteSystem := actor.NewActorSystem()
 	remote2 := remote.NewRemote(*remote.NewRemoteConfig("127.0.0.1:" + port2), remoteSystem)
-	remote2.Listen()
-	//defer remote2.Shutdown() // Ensure the listener is properly closed
+	if err := remote2.Start(); err != nil {
+		b.Fatalf("failed to start remote: %v", err)
+	}
+	defer remote2.Shutdown(context.Background()) // Ensure the listener is properly closed
 	remotePID, err := remote2.SpawnRemoteActor("127.0.0.1:"+port1, "BenchmarkReceiver")
 	if err != nil {
 		b.Fatalf("failed to spawn remote actor: %v", err)
@@ -2,6 +2,7 @@ package actor_spawn_benchmark_test
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -10,12 +11,13 @@ import (
 )
 
 type SumActor struct {
-	sum       int
-	count     int
-	level     int
-	mu        sync.Mutex
-	wg        *sync.WaitGroup
-	startTime time.Time // New field to store start time
+	sum        int
+	count      int
+	level      int
+	mu         sync.Mutex
+	wg         *sync.WaitGroup
+	startTime  time.Time        // New field to store start time
+	dispatcher actor.Dispatcher // Dispatcher used by children, default if nil
 }
 
 func (a *SumActor) Receive(ctx actor.ActorContext) {
@@ -47,7 +49,10 @@ func (a *SumActor) Receive(ctx actor.ActorContext) {
 				childOrdinal := msg.Ordinal*10 + i
 				self := ctx.Self()
 				props := actor.NewActorProps(self)
-				childPID, err := ctx.SpawnActor(&SumActor{level: a.level + 1, wg: a.wg}, *props)
+				if a.dispatcher != nil {
+					props.SetDispatcher(a.dispatcher)
+				}
+				childPID, err := ctx.SpawnActor(&SumActor{level: a.level + 1, wg: a.wg, dispatcher: a.dispatcher}, *props)
 				if err != nil {
 					fmt.Println("Failed to spawn child:", err)
 					return
@@ -73,11 +78,14 @@ type ResultMessage struct {
 
 var rootPID actor.PID
 
-func runBenchmark(b *testing.B) {
+func runBenchmark(b *testing.B, dispatcher actor.Dispatcher) {
 	for i := 0; i < b.N; i++ {
 		system := actor.NewActorSystem()
-		rootActor := &SumActor{level: 1, startTime: time.Now()} // Initialize startTime
+		rootActor := &SumActor{level: 1, startTime: time.Now(), dispatcher: dispatcher} // Initialize startTime
 		rootProps := actor.NewActorProps(nil)
+		if dispatcher != nil {
+			rootProps.SetDispatcher(dispatcher)
+		}
 		var err error
 
 		// Add a WaitGroup to wait for completion
@@ -100,5 +108,11 @@ func runBenchmark(b *testing.B) {
 }
 
 func BenchmarkActorSpawn(b *testing.B) {
-	runBenchmark(b)
+	runBenchmark(b, nil)
+}
+
+func BenchmarkActorSpawnWorkerPool(b *testing.B) {
+	dispatcher := actor.NewWorkerPoolDispatcher(runtime.NumCPU(), 300)
+	defer dispatcher.Stop()
+	runBenchmark(b, dispatcher)
 }
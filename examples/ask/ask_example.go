@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"light-actor-go/actor"
+	"time"
+)
+
+type Ping struct {
+	Value int
+}
+
+type Pong struct {
+	Value int
+}
+
+// EchoActor replies to every Ping with Pong
+type EchoActor struct{}
+
+func (a *EchoActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case Ping:
+		envelope := ctx.Envelope()
+		if sender := envelope.Sender(); sender != nil {
+			ctx.Send(Pong{Value: msg.Value}, *sender)
+		}
+	}
+}
+
+// SilentActor never replies, so request to it times out
+type SilentActor struct{}
+
+func (a *SilentActor) Receive(ctx actor.ActorContext) {}
+
+func main() {
+	actorSystem := actor.NewActorSystem()
+
+	echoPID, err := actorSystem.SpawnActor(&EchoActor{})
+	if err != nil {
+		fmt.Println("Error spawning echo actor:", err)
+		return
+	}
+
+	silentPID, err := actorSystem.SpawnActor(&SilentActor{})
+	if err != nil {
+		fmt.Println("Error spawning silent actor:", err)
+		return
+	}
+
+	future := actorSystem.Ask(echoPID, Ping{Value: 42}, time.Second)
+	result, err := future.Result()
+	fmt.Println("Echo actor replied:", result, err)
+
+	future = actorSystem.Ask(silentPID, Ping{Value: 7}, 500*time.Millisecond)
+	result, err = future.Result()
+	fmt.Println("Silent actor replied:", result, err)
+
+	actorSystem.GracefulStop(echoPID)
+	actorSystem.GracefulStop(silentPID)
+
+	time.Sleep(1 * time.Second)
+}
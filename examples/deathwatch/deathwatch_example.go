@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"light-actor-go/actor"
+	"time"
+)
+
+type WatchMessage struct {
+	Target actor.PID
+}
+
+// WatcherActor watches other actors and prints when they terminate
+type WatcherActor struct{}
+
+func (a *WatcherActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case WatchMessage:
+		fmt.Println("Watcher started watching:", msg.Target)
+		ctx.Watch(msg.Target)
+	case actor.Terminated:
+		fmt.Printf("Watcher received terminated: %v, reason: %v\n", msg.Who, msg.Reason)
+	}
+}
+
+// WorkerActor does nothing, it is only stopped
+type WorkerActor struct{}
+
+func (a *WorkerActor) Receive(ctx actor.ActorContext) {}
+
+func main() {
+	actorSystem := actor.NewActorSystem()
+
+	watcherPID, err := actorSystem.SpawnActor(&WatcherActor{})
+	if err != nil {
+		fmt.Println("Error spawning watcher actor:", err)
+		return
+	}
+
+	workerPID, err := actorSystem.SpawnActor(&WorkerActor{})
+	if err != nil {
+		fmt.Println("Error spawning worker actor:", err)
+		return
+	}
+
+	actorSystem.Send(actor.NewEnvelope(WatchMessage{Target: workerPID}, watcherPID))
+
+	// Watching actor that does not exist results in immediate terminated message
+	missingPID, _ := actor.NewPID()
+	actorSystem.Send(actor.NewEnvelope(WatchMessage{Target: missingPID}, watcherPID))
+
+	time.Sleep(1 * time.Second)
+
+	actorSystem.Stop(workerPID)
+
+	time.Sleep(1 * time.Second)
+
+	actorSystem.GracefulStop(watcherPID)
+}
@@ -26,17 +26,14 @@ func (a *ParentActor) Receive(ctx actor.ActorContext) {
 
 			actorSystem.Send(actor.NewEnvelope("SpawnGrandchild", childPID))
 		}
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageStop:
-			fmt.Println("Parent actor received stop message")
-		case actor.SystemMessageGracefulStop:
-			fmt.Println("Parent actor received graceful stop message")
-		case actor.SystemMessageChildTerminated:
-			fmt.Println("Parent actor received child terminated message")
-		case actor.SystemMessageStart:
-			fmt.Println("Parent actor received start message")
-		}
+	case actor.Started:
+		fmt.Println("Parent actor received started message")
+	case actor.Restarting:
+		fmt.Printf("Parent actor received restarting message, reason: %v\n", msg.Reason)
+	case actor.Stopping:
+		fmt.Println("Parent actor received stopping message")
+	case actor.Stopped:
+		fmt.Println("Parent actor received stopped message")
 	}
 
 }
@@ -56,17 +53,14 @@ func (a *ChildActor) Receive(ctx actor.ActorContext) {
 			ctx.SpawnActor(&GrandChildActor{}, *grandChildProps)
 
 		}
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageStop:
-			fmt.Println("Child actor received stop message")
-		case actor.SystemMessageGracefulStop:
-			fmt.Println("Child actor received graceful stop message")
-		case actor.SystemMessageChildTerminated:
-			fmt.Println("Child actor received child terminated message")
-		case actor.SystemMessageStart:
-			fmt.Println("Child actor received start message")
-		}
+	case actor.Started:
+		fmt.Println("Child actor received started message")
+	case actor.Restarting:
+		fmt.Printf("Child actor received restarting message, reason: %v\n", msg.Reason)
+	case actor.Stopping:
+		fmt.Println("Child actor received stopping message")
+	case actor.Stopped:
+		fmt.Println("Child actor received stopped message")
 	}
 }
 
@@ -79,17 +73,14 @@ func (a *GrandChildActor) Receive(ctx actor.ActorContext) {
 	switch msg := ctx.Message().(type) {
 	case string:
 		fmt.Println("Grandchild actor received:", msg)
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageStop:
-			fmt.Println("Grandchild actor received stop message")
-		case actor.SystemMessageGracefulStop:
-			fmt.Println("Grandchild actor received graceful stop message")
-		case actor.SystemMessageChildTerminated:
-			fmt.Println("Grandchild actor received child terminated message")
-		case actor.SystemMessageStart:
-			fmt.Println("Grandchild actor received start message")
-		}
+	case actor.Started:
+		fmt.Println("Grandchild actor received started message")
+	case actor.Restarting:
+		fmt.Printf("Grandchild actor received restarting message, reason: %v\n", msg.Reason)
+	case actor.Stopping:
+		fmt.Println("Grandchild actor received stopping message")
+	case actor.Stopped:
+		fmt.Println("Grandchild actor received stopped message")
 	}
 }
 
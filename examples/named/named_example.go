@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"light-actor-go/actor"
+	"time"
+)
+
+type CreateOrder struct {
+	ID int
+}
+
+// OrdersActor spawns named child for every order
+type OrdersActor struct{}
+
+func (a *OrdersActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case CreateOrder:
+		pid, err := ctx.SpawnNamed(fmt.Sprintf("order-%d", msg.ID), &OrderActor{})
+		if err != nil {
+			fmt.Println("Error spawning order actor:", err)
+			return
+		}
+		fmt.Println("Orders actor spawned:", pid)
+	}
+}
+
+// OrderActor prints messages it receives
+type OrderActor struct{}
+
+func (a *OrderActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case string:
+		fmt.Printf("Order actor %v received: %s\n", ctx.Self(), msg)
+	}
+}
+
+func main() {
+	actorSystem := actor.NewActorSystem()
+
+	ordersPID, err := actorSystem.SpawnNamed("orders", &OrdersActor{})
+	if err != nil {
+		fmt.Println("Error spawning orders actor:", err)
+		return
+	}
+	fmt.Println("Spawned:", ordersPID)
+
+	// Spawning actor with name that is taken fails
+	_, err = actorSystem.SpawnNamed("orders", &OrdersActor{})
+	fmt.Println("Spawning second orders actor:", err)
+
+	actorSystem.Send(actor.NewEnvelope(CreateOrder{ID: 42}, ordersPID))
+	actorSystem.Send(actor.NewEnvelope(CreateOrder{ID: 42}, ordersPID))
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Actor can be found by its path
+	if orderPID, ok := actorSystem.Registry().Lookup("/user/orders/order-42"); ok {
+		actorSystem.Send(actor.NewEnvelope("Hello order", orderPID))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	actorSystem.GracefulStop(ordersPID)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok := actorSystem.Registry().Lookup("/user/orders/order-42")
+	fmt.Println("Order actor found after stop:", ok)
+}
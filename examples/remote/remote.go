@@ -1,40 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"light-actor-go/actor"
 	"light-actor-go/examples/remote/messages"
 	"light-actor-go/remote"
 	"time"
-
-	"github.com/google/uuid"
-	"google.golang.org/protobuf/types/known/anypb"
 )
 
+// StringMessage is plain Go struct, it is sent as JSON and has to be registered on both nodes
 type StringMessage struct {
 	Value string
 }
 
+type StartPing struct {
+	Target actor.PID
+}
+
 type PingActor struct{}
 
 func (p *PingActor) Receive(context actor.ActorContext) {
 	switch msg := context.Message().(type) {
-	case *anypb.Any:
-		stringMsg := &messages.StringMessage{}
-		if err := msg.UnmarshalTo(stringMsg); err != nil {
-			fmt.Printf("Error unmarshalling message: %v\n", err)
-			return
-		}
-		fmt.Printf("PingActor received: %s\n", string(stringMsg.Value))
-		id, err := uuid.Parse(string(stringMsg.Value))
-		if err != nil {
-			fmt.Printf("Error parsing UUID: %v\n", err)
-			return
-		}
-		// Respond with a pong message
-		context.Send(&messages.StringMessage{Value: "Ping"}, actor.PID{ID: id})
 	case *messages.StringMessage:
-
+		// Proto messages are deserialized without registration
+		fmt.Printf("PingActor received: %s from %v\n", msg.Value, context.Sender())
+		// Respond with a pong message, sender PID carries address of its node so reply is routed back
+		context.Respond(StringMessage{Value: "Pong"})
 	default:
 		//fmt.Printf("PingActor received an unknown message type: %T\n", msg)
 	}
@@ -43,9 +35,11 @@ func (p *PingActor) Receive(context actor.ActorContext) {
 type PongActor struct{}
 
 func (p *PongActor) Receive(context actor.ActorContext) {
-	switch context.Message().(type) {
-	case *anypb.Any:
-		fmt.Println("PongActor received ping")
+	switch msg := context.Message().(type) {
+	case StartPing:
+		context.Send(&messages.StringMessage{Value: "Ping"}, msg.Target)
+	case StringMessage:
+		fmt.Println("PongActor received", msg.Value)
 		fmt.Println("Ping Pong interaction completed")
 	default:
 		//fmt.Println("Unknown message type in pong", msg)
@@ -56,7 +50,11 @@ func main() {
 	// Setup Actor Systems and Remote Communication
 	pingSystem := actor.NewActorSystem()
 	remote1 := remote.NewRemote(*remote.NewRemoteConfig("127.0.0.1:8091"), pingSystem)
-	remote1.Listen()
+	remote1.Serializers().Register(StringMessage{}, remote.JSONSerializer)
+	if err := remote1.Start(); err != nil {
+		fmt.Println("Error starting remote:", err)
+		return
+	}
 
 	pingActor := &PingActor{}
 	pingActorID, err := pingSystem.SpawnActor(pingActor)
@@ -68,7 +66,11 @@ func main() {
 
 	pongSystem := actor.NewActorSystem()
 	remote2 := remote.NewRemote(*remote.NewRemoteConfig("127.0.0.1:8092"), pongSystem)
-	remote2.Listen()
+	remote2.Serializers().Register(StringMessage{}, remote.JSONSerializer)
+	if err := remote2.Start(); err != nil {
+		fmt.Println("Error starting remote:", err)
+		return
+	}
 
 	pongActor := &PongActor{}
 	pongActorID, err := pongSystem.SpawnActor(pongActor)
@@ -82,18 +84,36 @@ func main() {
 	time.Sleep(time.Second * 1)
 
 	// Initiate Ping Pong Interaction
-	remoteID, _ := remote2.SpawnRemoteActor("127.0.0.1:8091", "PingActor")
-
-	remoteID2, _ := remote1.SpawnRemoteActor("127.0.0.1:8092", "PongActor")
-	pingMessage := &messages.StringMessage{Value: remoteID2.ID.String()}
+	remoteID, err := remote2.SpawnRemoteActor("127.0.0.1:8091", "PingActor")
+	if err != nil {
+		fmt.Println("Error resolving PingActor:", err)
+		return
+	}
+	fmt.Println("Resolved PingActor:", remoteID)
 
-	// Send initial ping from remote1 to remote2
-	pongSystem.Send(actor.NewEnvelope(pingMessage, remoteID))
+	// Pong actor sends ping from remote2 to remote1
+	pongSystem.Send(actor.NewEnvelope(StartPing{Target: remoteID}, pongActorID))
 
 	// Wait for completion (optional)
 	time.Sleep(time.Second * 3)
 
+	// Ask waits for reply on this node, unknown name is reported as error
+	reply, err := remote2.Ask("127.0.0.1:8091", "PingActor", &messages.StringMessage{Value: "Ping"}, time.Second).Result()
+	fmt.Println("Ask PingActor:", reply, err)
+	_, err = remote2.Ask("127.0.0.1:8091", "MissingActor", &messages.StringMessage{Value: "Ping"}, time.Second).Result()
+	fmt.Println("Ask MissingActor:", err)
+
 	// Shutdown actors and remote pingSystems (deferred)
 	defer pingSystem.GracefulStop(pingActorID)
 	defer pongSystem.GracefulStop(pongActorID)
+
+	// Remotes send queued messages before they stop, shutdown does not wait longer than 5 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := remote2.Shutdown(ctx); err != nil {
+		fmt.Println("Error shutting down remote:", err)
+	}
+	if err := remote1.Shutdown(ctx); err != nil {
+		fmt.Println("Error shutting down remote:", err)
+	}
 }
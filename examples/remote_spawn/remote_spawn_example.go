@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"time"
+)
+
+type Greet struct {
+	Name string
+}
+
+type Greeting struct {
+	Text string
+}
+
+// GreeterActor is spawned by other node, it replies to every greet
+type GreeterActor struct{}
+
+func (a *GreeterActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case Greet:
+		ctx.Respond(Greeting{Text: fmt.Sprintf("Hello %s from %v", msg.Name, ctx.Self())})
+	}
+}
+
+func newRemote(address string) *remote.Remote {
+	r := remote.NewRemote(*remote.NewRemoteConfig(address), actor.NewActorSystem())
+	r.Serializers().Register(Greet{}, remote.JSONSerializer)
+	r.Serializers().Register(Greeting{}, remote.JSONSerializer)
+	return r
+}
+
+func main() {
+	workerNode := newRemote("127.0.0.1:8093")
+	workerNode.RegisterKind("greeter", func() actor.Actor { return &GreeterActor{} })
+	if err := workerNode.Start(); err != nil {
+		fmt.Println("Error starting worker node:", err)
+		return
+	}
+
+	clientNode := newRemote("127.0.0.1:8094")
+	if err := clientNode.Start(); err != nil {
+		fmt.Println("Error starting client node:", err)
+		return
+	}
+
+	// Every spawn creates new actor on worker node
+	for _, name := range []string{"alice", "bob"} {
+		pid, err := clientNode.SpawnRemoteNamed("127.0.0.1:8093", "greeter", name)
+		if err != nil {
+			fmt.Println("Error spawning greeter:", err)
+			return
+		}
+		fmt.Println("Spawned greeter:", pid)
+
+		reply, err := clientNode.AskPID(pid, Greet{Name: name}, time.Second).Result()
+		if err != nil {
+			fmt.Println("Error asking greeter:", err)
+			return
+		}
+		fmt.Println(reply.(Greeting).Text)
+	}
+
+	// Kind has to be registered on worker node
+	if _, err := clientNode.SpawnRemote("127.0.0.1:8093", "unknown"); err != nil {
+		fmt.Println("Error spawning unknown kind:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	clientNode.Shutdown(ctx)
+	workerNode.Shutdown(ctx)
+}
@@ -45,11 +45,6 @@ func (a *ParentActor) Receive(ctx actor.ActorContext) {
 				actorSystem.Send(actor.NewEnvelope("Hellooo", *child))
 			}
 		}
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageFailure:
-
-		}
 	}
 }
 
@@ -79,28 +74,14 @@ func (a *ChildActor) Receive(ctx actor.ActorContext) {
 			}
 		}
 
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageFailure:
-			failure := msg.Extras.(actor.Failure)
-			fmt.Printf("Child actor received failure: %v from %v\n", failure.Reason, failure.Who)
-		case actor.SystemMessageStop:
-			fmt.Println("Child actor received stop message")
-		case actor.SystemMessageGracefulStop:
-			fmt.Println("Child actor received graceful stop message")
-		case actor.SystemMessageChildTerminated:
-			fmt.Println("Child actor received child terminated message")
-		case actor.SystemMessageStart:
-			fmt.Println("Child actor received start message")
-		case actor.SuspendMailbox:
-			fmt.Println("Child actor received suspend mailbox message")
-		case actor.ResumeMailbox:
-			fmt.Println("Child actor received resume mailbox message")
-		case actor.SuspendMailboxAll:
-			fmt.Println("Child actor received suspend mailbox all message")
-		case actor.ResumeMailboxAll:
-			fmt.Println("Child actor received resume mailbox all message")
-		}
+	case actor.Started:
+		fmt.Println("Child actor received started message")
+	case actor.Restarting:
+		fmt.Printf("Child actor received restarting message, reason: %v\n", msg.Reason)
+	case actor.Stopping:
+		fmt.Println("Child actor received stopping message")
+	case actor.Stopped:
+		fmt.Println("Child actor received stopped message")
 
 	}
 }
@@ -114,28 +95,14 @@ func (a *GrandChildActor) Receive(ctx actor.ActorContext) {
 	switch msg := ctx.Message().(type) {
 	case string:
 		fmt.Println("Grandchild actor received:", msg)
-	case actor.SystemMessage:
-		switch msg.Type {
-		case actor.SystemMessageFailure:
-			failure := msg.Extras.(actor.Failure)
-			fmt.Printf("Grandchild actor received failure: %v from %v\n", failure.Reason, failure.Who)
-		case actor.SystemMessageStop:
-			fmt.Println("Grandchild actor received stop message")
-		case actor.SystemMessageGracefulStop:
-			fmt.Println("Grandchild actor received graceful stop message")
-		case actor.SystemMessageChildTerminated:
-			fmt.Println("Grandchild actor received child terminated message")
-		case actor.SystemMessageStart:
-			fmt.Println("Grandchild actor received start message")
-		case actor.SuspendMailbox:
-			fmt.Println("Grandchild actor received suspend mailbox message")
-		case actor.ResumeMailbox:
-			fmt.Println("Grandchild actor received resume mailbox message")
-		case actor.SuspendMailboxAll:
-			fmt.Println("Grandchild actor received suspend mailbox all message")
-		case actor.ResumeMailboxAll:
-			fmt.Println("Grandchild actor received resume mailbox all message")
-		}
+	case actor.Started:
+		fmt.Println("Grandchild actor received started message")
+	case actor.Restarting:
+		fmt.Printf("Grandchild actor received restarting message, reason: %v\n", msg.Reason)
+	case actor.Stopping:
+		fmt.Println("Grandchild actor received stopping message")
+	case actor.Stopped:
+		fmt.Println("Grandchild actor received stopped message")
 	}
 }
 
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"light-actor-go/actor"
+	"time"
+)
+
+type Tick struct{}
+
+type Reminder struct{}
+
+// TimerActor schedules messages to itself, timers are cancelled automatically when actor stops
+type TimerActor struct {
+	ticks      int
+	cancelTick actor.CancelFunc
+}
+
+func (a *TimerActor) Receive(ctx actor.ActorContext) {
+	switch ctx.Message().(type) {
+	case actor.Started:
+		a.cancelTick = ctx.SendRepeatedly(100*time.Millisecond, 200*time.Millisecond, Tick{}, *ctx.Self())
+		ctx.SendAfter(time.Second, Reminder{}, *ctx.Self())
+		ctx.SetReceiveTimeout(500 * time.Millisecond)
+	case Tick:
+		a.ticks++
+		fmt.Println("Timer actor received tick", a.ticks)
+		if a.ticks == 3 {
+			a.cancelTick()
+		}
+	case Reminder:
+		fmt.Println("Timer actor received reminder")
+	case actor.ReceiveTimeout:
+		fmt.Println("Timer actor received no messages for a while")
+	case actor.Stopped:
+		fmt.Println("Timer actor stopped, timers are cancelled")
+	}
+}
+
+func main() {
+	actorSystem := actor.NewActorSystem()
+
+	pid, err := actorSystem.SpawnActor(&TimerActor{})
+	if err != nil {
+		fmt.Println("Error spawning timer actor:", err)
+		return
+	}
+
+	time.Sleep(3 * time.Second)
+
+	actorSystem.Stop(pid)
+
+	time.Sleep(1 * time.Second)
+}
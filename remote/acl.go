@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrNodesNotVerified = errors.New("acl with nodes requires mutual tls")
+
+// ACL limits which nodes can send to name of discoverable actor and which messages they can send.
+// Nodes are identified by client certificates, so ACL with nodes requires mutual TLS. Auth secret
+// is shared by all nodes and does not prove which node signed
+type ACL struct {
+	Nodes    []string      // Addresses of nodes that can resolve and send to actor, every node when empty
+	Messages []interface{} // Values of message types actor accepts from other nodes, every type when empty
+	// Nodes can remove name of actor with UnregisterRemote, names are removed only by this node when false
+	RemoteUnregister bool
+}
+
+// accessList is ACL with message types resolved to names they are sent under
+type accessList struct {
+	nodes      map[string]struct{}
+	types      map[string]struct{}
+	unregister bool
+}
+
+func newAccessList(acl ACL, serializers *SerializerRegistry) (*accessList, error) {
+	list := &accessList{unregister: acl.RemoteUnregister}
+	if len(acl.Nodes) > 0 {
+		list.nodes = make(map[string]struct{}, len(acl.Nodes))
+		for _, node := range acl.Nodes {
+			list.nodes[node] = struct{}{}
+		}
+	}
+	if len(acl.Messages) > 0 {
+		list.types = make(map[string]struct{}, len(acl.Messages))
+		for _, message := range acl.Messages {
+			name, err := serializers.TypeName(message)
+			if err != nil {
+				return nil, err
+			}
+			list.types[name] = struct{}{}
+		}
+	}
+	return list, nil
+}
+
+func (l *accessList) allowsNode(address string) bool {
+	if l == nil || l.nodes == nil {
+		return true
+	}
+	_, ok := l.nodes[address]
+	return ok
+}
+
+// Actor without access list can not be unregistered by other nodes
+func (l *accessList) allowsUnregister(address string) bool {
+	return l != nil && l.unregister && l.allowsNode(address)
+}
+
+func (l *accessList) allowsType(typeName string) bool {
+	if l == nil || l.types == nil {
+		return true
+	}
+	_, ok := l.types[typeName]
+	return ok
+}
+
+// Reports whether any of access lists allows node to send message of type, actor without
+// access lists accepts every message
+func allowsMessage(lists []*accessList, node string, typeName string) bool {
+	if len(lists) == 0 {
+		return true
+	}
+	for _, l := range lists {
+		if l.allowsNode(node) && l.allowsType(typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns sorted names of types accepted by actor, nil when every type is accepted
+func (l *accessList) messageTypes() []string {
+	if l == nil || l.types == nil {
+		return nil
+	}
+	types := make([]string, 0, len(l.types))
+	for name := range l.types {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
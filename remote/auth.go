@@ -0,0 +1,118 @@
+package remote
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	nodeAddressKey   = "x-node-address"
+	nodeTimestampKey = "x-node-timestamp"
+	nodeTokenKey     = "x-node-token"
+	maxTokenAge      = 5 * time.Minute // Also allowed clock difference between nodes
+)
+
+// nodeCredentials adds address of this node to every call, with HMAC token when secret is set.
+// Without TLS token can be replayed until it expires
+type nodeCredentials struct {
+	address string
+	secret  []byte
+}
+
+func (c nodeCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	md := map[string]string{nodeAddressKey: c.address}
+	if len(c.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		md[nodeTimestampKey] = timestamp
+		md[nodeTokenKey] = nodeToken(c.secret, c.address, timestamp)
+	}
+	return md, nil
+}
+
+func (c nodeCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func nodeToken(secret []byte, address string, timestamp string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(address + "\n" + timestamp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Returns address of node that made call. With mutual TLS address has to be covered by client
+// certificate, with secret it has to be signed by token, otherwise it is not verified
+func authenticate(ctx context.Context, config *RemoteConfig) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	address := firstValue(md, nodeAddressKey)
+	if config.TLS != nil && config.TLS.MutualTLS {
+		if err := verifyPeerCertificate(ctx, address); err != nil {
+			return "", err
+		}
+	}
+	secret := config.AuthSecret
+	if len(secret) == 0 {
+		return address, nil
+	}
+
+	timestamp := firstValue(md, nodeTimestampKey)
+	token := firstValue(md, nodeTokenKey)
+	if address == "" || token == "" {
+		return "", status.Error(codes.Unauthenticated, "missing node token")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, "invalid node token timestamp")
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > maxTokenAge || age < -maxTokenAge {
+		return "", status.Error(codes.Unauthenticated, "node token expired")
+	}
+	if !hmac.Equal([]byte(token), []byte(nodeToken(secret, address, timestamp))) {
+		return "", status.Error(codes.Unauthenticated, "invalid node token")
+	}
+	return address, nil
+}
+
+// Checks that verified client certificate is valid for host of address. Certificates do not
+// cover ports, so nodes on same host can claim addresses of each other
+func verifyPeerCertificate(ctx context.Context, address string) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return status.Error(codes.Unauthenticated, "missing client certificate")
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid node address %q", address)
+	}
+	if err := tlsInfo.State.VerifiedChains[0][0].VerifyHostname(host); err != nil {
+		return status.Errorf(codes.Unauthenticated, "client certificate is not valid for node %s", address)
+	}
+	return nil
+}
+
+// Reports whether addresses of calling nodes are verified by client certificates, so ACL with
+// nodes can be enforced. Any node holding auth secret can sign for address of other node
+func (rc *RemoteConfig) verifiesNodes() bool {
+	return rc.TLS != nil && rc.TLS.MutualTLS
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
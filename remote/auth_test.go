@@ -0,0 +1,302 @@
+package remote_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Pong struct {
+	Value string
+}
+
+// messageActor records values of pings and pongs it receives
+type messageActor struct {
+	received chan string
+}
+
+func (a *messageActor) Receive(ctx actor.ActorContext) {
+	switch msg := ctx.Message().(type) {
+	case Ping:
+		a.received <- msg.Value
+	case Pong:
+		a.received <- msg.Value
+	}
+}
+
+func newNodeConfig(t *testing.T, tlsConfig *remote.TLSConfig) *remote.RemoteConfig {
+	t.Helper()
+	config := remote.NewRemoteConfig(freeAddress(t))
+	config.TLS = tlsConfig
+	return config
+}
+
+func newAuthConfig(t *testing.T, secret string) *remote.RemoteConfig {
+	t.Helper()
+	config := newNodeConfig(t, nil)
+	if secret != "" {
+		config.AuthSecret = []byte(secret)
+	}
+	return config
+}
+
+// Returns config of node that requires client certificates signed by ca and presents ones issued by it
+func newMutualTLSConfig(t *testing.T, ca *certAuthority) *remote.RemoteConfig {
+	t.Helper()
+	return newNodeConfig(t, &remote.TLSConfig{
+		ServerCertificate: ca.issue(t, "node"),
+		ClientCertificate: ca.issue(t, "node"),
+		CAs:               ca.pool,
+		MutualTLS:         true,
+	})
+}
+
+// Starts node with config and discoverable actor named receiver, actor is protected by acl when it is not nil
+func startReceiverNodeWithACL(t *testing.T, config *remote.RemoteConfig, acl *remote.ACL) (string, chan string) {
+	t.Helper()
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	r.Serializers().Register(Pong{}, remote.JSONSerializer)
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start receiver node: %v", err)
+	}
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+
+	received := make(chan string, 1)
+	pid, err := system.SpawnActor(&messageActor{received: received})
+	if err != nil {
+		t.Fatalf("failed to spawn receiver: %v", err)
+	}
+	if acl != nil {
+		err = r.MakeActorDiscoverableWithACL(pid, "receiver", *acl)
+	} else {
+		err = r.MakeActorDiscoverable(pid, "receiver")
+	}
+	if err != nil {
+		t.Fatalf("failed to make receiver discoverable: %v", err)
+	}
+	return config.Addr, received
+}
+
+func startSenderNodeWithConfig(t *testing.T, config *remote.RemoteConfig) (*remote.Remote, *actor.ActorSystem) {
+	t.Helper()
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	r.Serializers().Register(Pong{}, remote.JSONSerializer)
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+	return r, system
+}
+
+// Issues client certificate of ca for ip, node using it can only claim addresses on that ip
+func issueForIP(t *testing.T, ca *certAuthority, name string, ip string) *tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP(ip)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestAuthToken(t *testing.T) {
+	address, received := startReceiverNodeWithACL(t, newAuthConfig(t, "secret"), nil)
+	sender, system := startSenderNodeWithConfig(t, newAuthConfig(t, "secret"))
+
+	sendPing(t, address, sender, system, received)
+}
+
+func TestAuthTokenRejected(t *testing.T) {
+	address, _ := startReceiverNodeWithACL(t, newAuthConfig(t, "secret"), nil)
+
+	tests := map[string]string{
+		"no token":     "",
+		"wrong secret": "other secret",
+	}
+	for name, secret := range tests {
+		t.Run(name, func(t *testing.T) {
+			sender, _ := startSenderNodeWithConfig(t, newAuthConfig(t, secret))
+			_, err := sender.SpawnRemoteActor(address, "receiver")
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("expected Unauthenticated, got %v", err)
+			}
+		})
+	}
+}
+
+func TestACLNodes(t *testing.T) {
+	ca := newCertAuthority(t)
+	allowedConfig := newMutualTLSConfig(t, ca)
+	address, received := startReceiverNodeWithACL(t, newMutualTLSConfig(t, ca), &remote.ACL{Nodes: []string{allowedConfig.Addr}})
+
+	allowed, system := startSenderNodeWithConfig(t, allowedConfig)
+	sendPing(t, address, allowed, system, received)
+
+	other, _ := startSenderNodeWithConfig(t, newMutualTLSConfig(t, ca))
+	_, err := other.SpawnRemoteActor(address, "receiver")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+
+	// certificate is valid for other host than address node claims
+	spoofing, _ := startSenderNodeWithConfig(t, newNodeConfig(t, &remote.TLSConfig{ClientCertificate: issueForIP(t, ca, "sender", "10.0.0.1"), CAs: ca.pool}))
+	if _, err := spoofing.SpawnRemoteActor(address, "receiver"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestACLNodesWithoutMutualTLS(t *testing.T) {
+	tests := map[string]*remote.RemoteConfig{
+		"no auth":     newNodeConfig(t, nil),
+		"auth secret": newAuthConfig(t, "secret"),
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := remote.NewRemote(*config, actor.NewActorSystem())
+			pid, _ := actor.NewPID()
+
+			err := r.MakeActorDiscoverableWithACL(pid, "receiver", remote.ACL{Nodes: []string{freeAddress(t)}})
+			if !errors.Is(err, remote.ErrNodesNotVerified) {
+				t.Fatalf("expected ErrNodesNotVerified, got %v", err)
+			}
+		})
+	}
+}
+
+func TestACLMessages(t *testing.T) {
+	address, received := startReceiverNodeWithACL(t, newNodeConfig(t, nil), &remote.ACL{Messages: []interface{}{Ping{}}})
+	sender, system := startSenderNodeWithConfig(t, newNodeConfig(t, nil))
+
+	pid, err := sender.SpawnRemoteActor(address, "receiver")
+	if err != nil {
+		t.Fatalf("failed to resolve receiver: %v", err)
+	}
+	// envelopes are delivered in order, pong would be received before ping if it was accepted
+	system.Send(actor.NewEnvelope(Pong{Value: "pong"}, pid))
+	system.Send(actor.NewEnvelope(Ping{Value: "ping"}, pid))
+	select {
+	case value := <-received:
+		if value != "ping" {
+			t.Fatalf("received %q, expected only ping", value)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+	}
+}
+
+func TestACLPerName(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	node, system := startSenderNodeWithConfig(t, config)
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	pid, err := system.SpawnActor(&echoActor{})
+	if err != nil {
+		t.Fatalf("failed to spawn echo actor: %v", err)
+	}
+	if err := node.MakeActorDiscoverableWithACL(pid, "pings", remote.ACL{Messages: []interface{}{Ping{}}}); err != nil {
+		t.Fatalf("failed to make actor discoverable: %v", err)
+	}
+	if err := node.MakeActorDiscoverableWithACL(pid, "pongs", remote.ACL{Messages: []interface{}{Pong{}}}); err != nil {
+		t.Fatalf("failed to make actor discoverable: %v", err)
+	}
+	sender := startAskingNode(t)
+
+	if _, err := sender.Ask(config.Addr, "pings", Ping{Value: "hello"}, 5*time.Second).Result(); err != nil {
+		t.Fatalf("ping to name that accepts it failed: %v", err)
+	}
+	_, err = sender.Ask(config.Addr, "pongs", Ping{Value: "hello"}, 5*time.Second).Result()
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	// PID of actor accepts messages accepted by any of its names
+	if _, err := sender.AskPID(resolve(t, sender, config.Addr, "pongs"), Ping{Value: "hello"}, 5*time.Second).Result(); err != nil {
+		t.Fatalf("ping to pid failed: %v", err)
+	}
+}
+
+func TestACLUnregisteredMessage(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	r := remote.NewRemote(*config, actor.NewActorSystem())
+	pid, _ := actor.NewPID()
+
+	type unregistered struct{}
+	if err := r.MakeActorDiscoverableWithACL(pid, "receiver", remote.ACL{Messages: []interface{}{unregistered{}}}); err == nil {
+		t.Fatal("expected error for message type that is not registered")
+	}
+}
+
+func TestForwardingToOtherNodeRejected(t *testing.T) {
+	target, received := startReceiverNode(t, nil)
+	relayConfig := newNodeConfig(t, nil)
+	relay, _ := startSenderNodeWithConfig(t, relayConfig)
+	if err := relay.Start(); err != nil {
+		t.Fatalf("failed to start relay node: %v", err)
+	}
+	pid, err := relay.Resolve(target, "receiver")
+	if err != nil {
+		t.Fatalf("failed to resolve receiver: %v", err)
+	}
+
+	conn, err := grpc.NewClient(relayConfig.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect to relay node: %v", err)
+	}
+	defer conn.Close()
+	client := remote.NewRemoteReceiverClient(conn)
+	typeName, data, err := relay.Serializers().Serialize(Ping{Value: "forwarded"})
+	if err != nil {
+		t.Fatalf("failed to serialize ping: %v", err)
+	}
+	envelope := &remote.Envelope{Receiver: relay.PIDToProto(pid), TypeName: typeName, Message: data}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.ReceiveMessage(ctx, envelope); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	stream, err := client.Stream(ctx)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	if err := stream.Send(&remote.MessageBatch{Id: 1, Envelopes: []*remote.Envelope{envelope}}); err != nil {
+		t.Fatalf("failed to send batch: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("batch was not acknowledged: %v", err)
+	}
+	select {
+	case value := <-received:
+		t.Fatalf("relay node forwarded %q", value)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	maxBatchSize       = 100 // Envelopes in one batch
+	maxInFlightBatches = 8   // Batches sent and not yet acknowledged by remote node
+)
+
+// pendingBatch keeps envelopes of batch so they can be resent or sent to dead letters
+type pendingBatch struct {
+	batch     *MessageBatch
+	envelopes []outboundEnvelope
+}
+
+// batchStream sends batches over one bidirectional stream. Remote node acknowledges batch after
+// its envelopes are delivered to actors, sending waits while maxInFlightBatches are not acknowledged
+type batchStream struct {
+	stream  RemoteReceiver_StreamClient
+	cancel  context.CancelFunc
+	window  chan struct{} // One slot per batch waiting for acknowledgement
+	unacked []pendingBatch
+	err     error         // Set before failed is closed
+	failed  chan struct{} // Closed when stream ends
+	stop    <-chan struct{}
+	mu      sync.Mutex
+}
+
+// Opens stream that ends when parent is cancelled
+func openBatchStream(parent context.Context, client RemoteReceiverClient) (*batchStream, error) {
+	ctx, cancel := context.WithCancel(parent)
+	stream, err := client.Stream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &batchStream{
+		stream: stream,
+		cancel: cancel,
+		window: make(chan struct{}, maxInFlightBatches),
+		failed: make(chan struct{}),
+		stop:   parent.Done(),
+	}
+	go s.receiveAcks()
+	return s, nil
+}
+
+// Sends batch, waiting while window is full. On error batch is kept with unacknowledged batches
+func (s *batchStream) send(p pendingBatch) error {
+	s.mu.Lock()
+	s.unacked = append(s.unacked, p)
+	s.mu.Unlock()
+	select {
+	case s.window <- struct{}{}:
+	case <-s.failed:
+		return s.err
+	case <-s.stop:
+		return ErrRemoteStopped
+	}
+	return s.stream.Send(p.batch)
+}
+
+func (s *batchStream) receiveAcks() {
+	for {
+		ack, err := s.stream.Recv()
+		if err != nil {
+			s.err = err
+			close(s.failed)
+			return
+		}
+		s.mu.Lock()
+		for len(s.unacked) > 0 && s.unacked[0].batch.Id <= ack.Id {
+			s.unacked[0] = pendingBatch{}
+			s.unacked = s.unacked[1:]
+			<-s.window
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Cancels stream and returns batches that were not acknowledged, in order they were sent
+func (s *batchStream) abort() []pendingBatch {
+	s.cancel()
+	<-s.failed
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unacked := s.unacked
+	s.unacked = nil
+	return unacked
+}
+
+// Closes sending side and waits until remote node acknowledges all batches,
+// returns batches that were not acknowledged before stream ended or parent was cancelled
+func (s *batchStream) close() []pendingBatch {
+	if err := s.stream.CloseSend(); err == nil {
+		select {
+		case <-s.failed:
+		case <-s.stop:
+		}
+	}
+	return s.abort()
+}
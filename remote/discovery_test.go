@@ -0,0 +1,205 @@
+package remote_test
+
+import (
+	"context"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Starts node with actors made discoverable under names, actor named with acl key is protected by it
+func startDiscoveryNode(t *testing.T, config *remote.RemoteConfig, names []string, acls map[string]remote.ACL) (*remote.Remote, *actor.ActorSystem) {
+	t.Helper()
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+
+	for _, name := range names {
+		pid, err := system.SpawnActor(&receiverActor{received: make(chan string, 1)})
+		if err != nil {
+			t.Fatalf("failed to spawn %s: %v", name, err)
+		}
+		if acl, exists := acls[name]; exists {
+			err = r.MakeActorDiscoverableWithACL(pid, name, acl)
+		} else {
+			err = r.MakeActorDiscoverable(pid, name)
+		}
+		if err != nil {
+			t.Fatalf("failed to make %s discoverable: %v", name, err)
+		}
+	}
+	return r, system
+}
+
+func listNames(t *testing.T, r *remote.Remote, address string, prefix string) []string {
+	t.Helper()
+	infos, err := r.List(address, prefix)
+	if err != nil {
+		t.Fatalf("failed to list actors: %v", err)
+	}
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+func equalNames(first []string, second []string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestList(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	before := time.Now().Add(-time.Second)
+	startDiscoveryNode(t, config, []string{"worker/b", "worker/a", "logger"}, map[string]remote.ACL{
+		"logger": {Messages: []interface{}{Ping{}}},
+	})
+	sender, _ := startSenderNode(t, nil)
+
+	infos, err := sender.List(config.Addr, "")
+	if err != nil {
+		t.Fatalf("failed to list actors: %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("listed %d actors, expected 3", len(infos))
+	}
+	logger := infos[0]
+	if logger.Name != "logger" || logger.PID.Address != config.Addr {
+		t.Fatalf("unexpected first actor %s at %s", logger.Name, logger.PID.Address)
+	}
+	if logger.RegisteredAt.Before(before) || logger.RegisteredAt.After(time.Now()) {
+		t.Fatalf("unexpected registration time %v", logger.RegisteredAt)
+	}
+	if len(logger.MessageTypes) != 1 || logger.MessageTypes[0] != "light-actor-go/remote_test.Ping" {
+		t.Fatalf("unexpected message types %v", logger.MessageTypes)
+	}
+	if infos[1].MessageTypes != nil {
+		t.Fatalf("expected no message types for actor without acl, got %v", infos[1].MessageTypes)
+	}
+
+	if names := listNames(t, sender, config.Addr, "worker/"); !equalNames(names, []string{"worker/a", "worker/b"}) {
+		t.Fatalf("listed %v, expected workers", names)
+	}
+}
+
+func TestListFiltersByACL(t *testing.T) {
+	ca := newCertAuthority(t)
+	allowedConfig := newMutualTLSConfig(t, ca)
+	config := newMutualTLSConfig(t, ca)
+	startDiscoveryNode(t, config, []string{"private", "public"}, map[string]remote.ACL{
+		"private": {Nodes: []string{allowedConfig.Addr}},
+	})
+
+	allowed, _ := startSenderNodeWithConfig(t, allowedConfig)
+	if names := listNames(t, allowed, config.Addr, ""); !equalNames(names, []string{"private", "public"}) {
+		t.Fatalf("listed %v for allowed node", names)
+	}
+	other, _ := startSenderNodeWithConfig(t, newMutualTLSConfig(t, ca))
+	if names := listNames(t, other, config.Addr, ""); !equalNames(names, []string{"public"}) {
+		t.Fatalf("listed %v for other node", names)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	startDiscoveryNode(t, config, []string{"worker"}, nil)
+	sender, _ := startSenderNode(t, nil)
+
+	pid, err := sender.Resolve(config.Addr, "worker")
+	if err != nil {
+		t.Fatalf("failed to resolve worker: %v", err)
+	}
+	if pid.Address != config.Addr {
+		t.Fatalf("resolved pid with address %s, expected %s", pid.Address, config.Addr)
+	}
+	if _, err := sender.Resolve(config.Addr, "unknown"); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestUnregisterRemote(t *testing.T) {
+	ca := newCertAuthority(t)
+	allowedConfig := newMutualTLSConfig(t, ca)
+	config := newMutualTLSConfig(t, ca)
+	startDiscoveryNode(t, config, []string{"worker", "private", "fixed"}, map[string]remote.ACL{
+		"worker":  {RemoteUnregister: true},
+		"private": {Nodes: []string{allowedConfig.Addr}, RemoteUnregister: true},
+	})
+	sender, _ := startSenderNodeWithConfig(t, newMutualTLSConfig(t, ca))
+
+	if err := sender.UnregisterRemote(config.Addr, "worker"); err != nil {
+		t.Fatalf("failed to unregister worker: %v", err)
+	}
+	if _, err := sender.Resolve(config.Addr, "worker"); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound after unregister, got %v", err)
+	}
+	if err := sender.UnregisterRemote(config.Addr, "worker"); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown name, got %v", err)
+	}
+	if err := sender.UnregisterRemote(config.Addr, "private"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for node not in acl, got %v", err)
+	}
+	if err := sender.UnregisterRemote(config.Addr, "fixed"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied without remote unregister, got %v", err)
+	}
+
+	allowed, _ := startSenderNodeWithConfig(t, allowedConfig)
+	if err := allowed.UnregisterRemote(config.Addr, "private"); err != nil {
+		t.Fatalf("failed to unregister private: %v", err)
+	}
+}
+
+func TestUnregisterLocal(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	node, _ := startDiscoveryNode(t, config, []string{"worker"}, nil)
+
+	if !node.Unregister("worker") {
+		t.Fatal("expected worker to be unregistered")
+	}
+	if node.Unregister("worker") {
+		t.Fatal("expected second unregister to report missing name")
+	}
+	if infos := node.DiscoverableActors(""); len(infos) != 0 {
+		t.Fatalf("expected no discoverable actors, got %d", len(infos))
+	}
+}
+
+func TestRemovedWhenActorStops(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	node, system := startDiscoveryNode(t, config, []string{"worker", "other"}, nil)
+	sender, _ := startSenderNode(t, nil)
+
+	workers := node.DiscoverableActors("worker")
+	if len(workers) != 1 {
+		t.Fatalf("expected one worker, got %d", len(workers))
+	}
+	system.Stop(workers[0].PID)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		names := listNames(t, sender, config.Addr, "")
+		if equalNames(names, []string{"other"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("listed %v after worker stopped", names)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,387 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"light-actor-go/actor"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrRemoteStopped = errors.New("remote is stopped")
+
+const (
+	endpointQueueSize       = 1000
+	initialReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff     = 5 * time.Second
+	maxReconnectAttempts    = 5
+	defaultIdleTimeout      = 5 * time.Minute
+)
+
+// endpoint sends envelopes to one remote node over single stream, in order they were queued
+type endpoint struct {
+	address     string
+	sender      *RemoteSender
+	queue       chan outboundEnvelope
+	stream      *batchStream // Current stream, used only by writer goroutine
+	nextBatchID uint64
+	ctx         context.Context // Cancelled when shutdown is cancelled, ends streams and waiting for reconnect
+	cancel      context.CancelFunc
+	done        chan struct{} // Closed when writer goroutine exits
+	users       atomic.Int32  // Callers that acquired endpoint and did not release it
+	lastUsed    atomic.Int64  // Unix nanoseconds of last release
+	evicted     bool          // Set when endpoint is removed from manager because it was idle
+}
+
+// endpointManager keeps one endpoint per remote address
+type endpointManager struct {
+	config      *RemoteConfig // Config of this node
+	actorSystem *actor.ActorSystem
+	serializers *SerializerRegistry
+	requests    *pendingRequests
+	endpoints   map[string]*endpoint
+	stopped     bool
+	sending     sync.WaitGroup         // Senders that may still put envelope in queue of endpoint
+	closing     map[*endpoint]struct{} // Evicted endpoints whose writers did not exit yet
+	mu          sync.RWMutex
+}
+
+func newEndpointManager(config *RemoteConfig, actorSystem *actor.ActorSystem, serializers *SerializerRegistry, requests *pendingRequests) *endpointManager {
+	return &endpointManager{
+		config:      config,
+		actorSystem: actorSystem,
+		serializers: serializers,
+		requests:    requests,
+		endpoints:   make(map[string]*endpoint),
+		closing:     make(map[*endpoint]struct{}),
+	}
+}
+
+// Returns endpoint for address, creating it if there is none. Endpoint is not evicted until it is
+// released. No endpoint is returned after shutdown
+func (em *endpointManager) acquire(address string) (*endpoint, error) {
+	em.mu.RLock()
+	e, exists := em.endpoints[address]
+	stopped := em.stopped
+	if exists && !stopped {
+		e.users.Add(1)
+	}
+	em.mu.RUnlock()
+	if stopped {
+		return nil, ErrRemoteStopped
+	}
+	if exists {
+		return e, nil
+	}
+
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if em.stopped {
+		return nil, ErrRemoteStopped
+	}
+	if e, exists := em.endpoints[address]; exists {
+		e.users.Add(1)
+		return e, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e = &endpoint{
+		address: address,
+		sender:  NewRemoteSender(address, em.config, em.serializers),
+		queue:   make(chan outboundEnvelope, endpointQueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	e.users.Add(1)
+	e.lastUsed.Store(time.Now().UnixNano())
+	em.endpoints[address] = e
+	go em.write(e)
+	return e, nil
+}
+
+func (em *endpointManager) release(e *endpoint) {
+	e.lastUsed.Store(time.Now().UnixNano())
+	e.users.Add(-1)
+}
+
+// Queues envelope for its node, envelopes sent after shutdown go to dead letters
+func (em *endpointManager) send(outbound outboundEnvelope) {
+	e, err := em.acquire(outbound.address)
+	if err != nil {
+		em.failed(outbound, err)
+		return
+	}
+	defer em.release(e)
+
+	// lock is not held while queue is full, so shutdown is not blocked by senders
+	em.mu.RLock()
+	if em.stopped {
+		em.mu.RUnlock()
+		em.failed(outbound, ErrRemoteStopped)
+		return
+	}
+	em.sending.Add(1)
+	em.mu.RUnlock()
+	defer em.sending.Done()
+
+	select {
+	case e.queue <- outbound:
+	case <-e.ctx.Done():
+		em.failed(outbound, ErrRemoteStopped)
+	}
+}
+
+// Calls call with sender connected to address, used for calls other than sending messages
+func (em *endpointManager) call(address string, call func(sender *RemoteSender) error) error {
+	e, err := em.acquire(address)
+	if err != nil {
+		return err
+	}
+	defer em.release(e)
+	return call(e.sender)
+}
+
+func (em *endpointManager) write(e *endpoint) {
+	defer close(e.done)
+	for {
+		envelopes, open := em.nextBatch(e)
+		if len(envelopes) > 0 {
+			em.deliver(e, envelopes)
+		}
+		if !open {
+			break
+		}
+	}
+	if e.stream != nil {
+		em.deadLetter(e.stream.close(), nil)
+		e.stream = nil
+	}
+	e.sender.Close()
+	if e.evicted {
+		em.mu.Lock()
+		delete(em.closing, e)
+		em.mu.Unlock()
+		e.cancel()
+	}
+}
+
+// Removes endpoint that was not used for idle timeout, so its writer can exit. Endpoint that is
+// used or has queued envelopes is kept, senders acquire new endpoint once it is removed
+func (em *endpointManager) evict(e *endpoint) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	idle := time.Since(time.Unix(0, e.lastUsed.Load()))
+	if em.stopped || e.users.Load() > 0 || len(e.queue) > 0 || idle < em.idleTimeout() {
+		return false
+	}
+	delete(em.endpoints, e.address)
+	e.evicted = true
+	em.closing[e] = struct{}{}
+	return true
+}
+
+func (em *endpointManager) idleTimeout() time.Duration {
+	if em.config.IdleTimeout > 0 {
+		return em.config.IdleTimeout
+	}
+	return defaultIdleTimeout
+}
+
+// Waits for envelope and takes ones queued after it, returns false when queue is closed or
+// endpoint is evicted. If stream ends while waiting, batches it did not acknowledge are sent over new stream
+func (em *endpointManager) nextBatch(e *endpoint) ([]outboundEnvelope, bool) {
+	var envelope outboundEnvelope
+	idle := time.NewTimer(em.idleTimeout())
+	defer idle.Stop()
+	for received := false; !received; {
+		var failed chan struct{}
+		if e.stream != nil {
+			failed = e.stream.failed
+		}
+		select {
+		case next, open := <-e.queue:
+			if !open {
+				return nil, false
+			}
+			envelope = next
+			received = true
+		case <-failed:
+			pending := e.stream.abort()
+			e.stream = nil
+			if len(pending) > 0 {
+				em.sendPending(e, pending)
+			}
+		case <-idle.C:
+			if em.evict(e) {
+				return nil, false
+			}
+			idle.Reset(em.idleTimeout())
+		}
+	}
+
+	envelopes := []outboundEnvelope{envelope}
+	for len(envelopes) < maxBatchSize {
+		select {
+		case envelope, open := <-e.queue:
+			if !open {
+				return envelopes, false
+			}
+			envelopes = append(envelopes, envelope)
+		default:
+			return envelopes, true
+		}
+	}
+	return envelopes, true
+}
+
+// Sends envelopes as one batch
+func (em *endpointManager) deliver(e *endpoint, envelopes []outboundEnvelope) {
+	batch := pendingBatch{batch: &MessageBatch{}}
+	for _, envelope := range envelopes {
+		protoEnvelope, err := e.sender.toProto(envelope)
+		if err != nil {
+			log.Printf("[Remote] failed to send to %s: %v", envelope.address, err)
+			em.failed(envelope, err)
+			continue
+		}
+		batch.batch.Envelopes = append(batch.batch.Envelopes, protoEnvelope)
+		batch.envelopes = append(batch.envelopes, envelope)
+	}
+	if len(batch.envelopes) == 0 {
+		return
+	}
+	e.nextBatchID++
+	batch.batch.Id = e.nextBatchID
+	em.sendPending(e, []pendingBatch{batch})
+}
+
+// Sends batches in order. When stream breaks, batches that were not acknowledged
+// are sent again over new stream after backoff, batch that was delivered but not acknowledged is delivered twice
+func (em *endpointManager) sendPending(e *endpoint, pending []pendingBatch) {
+	backoff := initialReconnectBackoff
+	for attempt := 1; ; attempt++ {
+		sent, err := em.sendBatches(e, pending)
+		if err == nil {
+			return
+		}
+		if e.stream != nil {
+			pending = append(e.stream.abort(), pending[sent:]...)
+			e.stream = nil
+		}
+		if attempt == maxReconnectAttempts {
+			em.deadLetter(pending, err)
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-e.ctx.Done():
+			em.deadLetter(pending, err)
+			return
+		}
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+		e.sender.reconnect()
+	}
+}
+
+// Sends batches over current stream opening it if needed, returns number of batches sent
+func (em *endpointManager) sendBatches(e *endpoint, batches []pendingBatch) (int, error) {
+	if e.stream == nil {
+		stream, err := e.sender.openStream(e.ctx)
+		if err != nil {
+			return 0, err
+		}
+		e.stream = stream
+	}
+	for i, batch := range batches {
+		if err := e.stream.send(batch); err != nil {
+			return i + 1, err
+		}
+	}
+	return len(batches), nil
+}
+
+func (em *endpointManager) deadLetter(batches []pendingBatch, err error) {
+	if len(batches) == 0 {
+		return
+	}
+	if err == nil {
+		err = ErrRemoteStopped
+	}
+	log.Printf("[Remote] failed to send to %s: %v", batches[0].envelopes[0].address, err)
+	for _, batch := range batches {
+		for _, envelope := range batch.envelopes {
+			em.failed(envelope, err)
+		}
+	}
+}
+
+// Sends envelope that could not be sent to dead letters, request fails with err
+func (em *endpointManager) failed(outbound outboundEnvelope, err error) {
+	if outbound.err != nil {
+		return
+	}
+	if outbound.correlationID != 0 {
+		em.requests.fail(outbound.correlationID, err)
+		return
+	}
+	em.actorSystem.SendToDeadLetters(outbound.envelope, DeadLetterRemoteSendFailed)
+}
+
+// Stops accepting envelopes, sends queued ones and closes connections. When ctx is done
+// before that, streams are cancelled and envelopes that were not sent go to dead letters
+func (em *endpointManager) shutdown(ctx context.Context) error {
+	em.mu.Lock()
+	if em.stopped {
+		em.mu.Unlock()
+		return nil
+	}
+	em.stopped = true
+	endpoints := make([]*endpoint, 0, len(em.endpoints))
+	for _, e := range em.endpoints {
+		endpoints = append(endpoints, e)
+	}
+	for e := range em.closing {
+		endpoints = append(endpoints, e)
+	}
+	em.mu.Unlock()
+
+	var err error
+	wait := func(done <-chan struct{}) {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+		if err == nil {
+			err = ctx.Err()
+			for _, e := range endpoints {
+				e.cancel()
+			}
+		}
+		<-done
+	}
+
+	// queue is closed once no sender can put envelope in it
+	sent := make(chan struct{})
+	go func() {
+		em.sending.Wait()
+		close(sent)
+	}()
+	wait(sent)
+	for _, e := range endpoints {
+		if !e.evicted {
+			close(e.queue)
+		}
+	}
+	for _, e := range endpoints {
+		wait(e.done)
+		e.cancel()
+	}
+	return err
+}
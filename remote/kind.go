@@ -0,0 +1,46 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"light-actor-go/actor"
+	"sync"
+)
+
+var ErrKindExists = errors.New("actor kind already registered")
+
+// actorKind creates actors that other nodes spawn on this node
+type actorKind struct {
+	producer actor.ActorProducer
+	props    []actor.ActorProps
+	access   *accessList // Nodes that can spawn actors of kind, every node when nil
+}
+
+type kindRegistry struct {
+	kinds map[string]actorKind
+	mu    sync.RWMutex
+}
+
+func newKindRegistry() *kindRegistry {
+	return &kindRegistry{kinds: make(map[string]actorKind)}
+}
+
+func (kr *kindRegistry) add(kind string, producer actor.ActorProducer, props []actor.ActorProps, access *accessList) error {
+	if producer == nil {
+		return fmt.Errorf("actor kind %s has no producer", kind)
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if _, exists := kr.kinds[kind]; exists {
+		return fmt.Errorf("%w: %s", ErrKindExists, kind)
+	}
+	kr.kinds[kind] = actorKind{producer: producer, props: props, access: access}
+	return nil
+}
+
+func (kr *kindRegistry) find(kind string) (actorKind, bool) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	k, exists := kr.kinds[kind]
+	return k, exists
+}
@@ -0,0 +1,131 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Starts node with echo actor kind registered
+func startKindNode(t *testing.T) string {
+	t.Helper()
+	config := newNodeConfig(t, nil)
+	r := remote.NewRemote(*config, actor.NewActorSystem())
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	r.Serializers().Register(Pong{}, remote.JSONSerializer)
+	if err := r.RegisterKind("echo", func() actor.Actor { return &echoActor{} }); err != nil {
+		t.Fatalf("failed to register kind: %v", err)
+	}
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start kind node: %v", err)
+	}
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+	return config.Addr
+}
+
+func TestSpawnRemote(t *testing.T) {
+	address := startKindNode(t)
+	sender := startAskingNode(t)
+
+	first, err := sender.SpawnRemote(address, "echo")
+	if err != nil {
+		t.Fatalf("failed to spawn remote actor: %v", err)
+	}
+	second, err := sender.SpawnRemote(address, "echo")
+	if err != nil {
+		t.Fatalf("failed to spawn remote actor: %v", err)
+	}
+	if first.Equal(&second) {
+		t.Fatal("expected every spawn to create new actor")
+	}
+	if first.Address != address {
+		t.Fatalf("expected PID with address %s, got %v", address, first)
+	}
+
+	result, err := sender.AskPID(first, Ping{Value: "hello"}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("ask failed: %v", err)
+	}
+	if pong, ok := result.(Pong); !ok || pong.Value != "hello" {
+		t.Fatalf("received %#v, expected pong with hello", result)
+	}
+}
+
+func TestSpawnRemoteNamed(t *testing.T) {
+	address := startKindNode(t)
+	sender := startAskingNode(t)
+
+	pid, err := sender.SpawnRemoteNamed(address, "echo", "worker")
+	if err != nil {
+		t.Fatalf("failed to spawn remote actor: %v", err)
+	}
+	if pid.Path != "/user/worker" {
+		t.Fatalf("expected path /user/worker, got %s", pid.Path)
+	}
+
+	_, err = sender.SpawnRemoteNamed(address, "echo", "worker")
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestSpawnRemoteUnknownKind(t *testing.T) {
+	address := startKindNode(t)
+	sender := startAskingNode(t)
+
+	_, err := sender.SpawnRemote(address, "missing")
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestRegisterKindTwice(t *testing.T) {
+	r := remote.NewRemote(*newNodeConfig(t, nil), actor.NewActorSystem())
+	producer := func() actor.Actor { return &echoActor{} }
+
+	if err := r.RegisterKind("echo", producer); err != nil {
+		t.Fatalf("failed to register kind: %v", err)
+	}
+	if err := r.RegisterKind("echo", producer); !errors.Is(err, remote.ErrKindExists) {
+		t.Fatalf("expected ErrKindExists, got %v", err)
+	}
+}
+
+func TestSpawnRemoteForNodes(t *testing.T) {
+	ca := newCertAuthority(t)
+	config := newMutualTLSConfig(t, ca)
+	allowedConfig := newMutualTLSConfig(t, ca)
+	node, _ := startSenderNodeWithConfig(t, config)
+	producer := func() actor.Actor { return &echoActor{} }
+	if err := node.RegisterKindForNodes("echo", producer, []string{allowedConfig.Addr}); err != nil {
+		t.Fatalf("failed to register kind: %v", err)
+	}
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start kind node: %v", err)
+	}
+
+	allowed, _ := startSenderNodeWithConfig(t, allowedConfig)
+	if _, err := allowed.SpawnRemote(config.Addr, "echo"); err != nil {
+		t.Fatalf("failed to spawn remote actor: %v", err)
+	}
+	other, _ := startSenderNodeWithConfig(t, newMutualTLSConfig(t, ca))
+	if _, err := other.SpawnRemote(config.Addr, "echo"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestRegisterKindForNodesWithoutMutualTLS(t *testing.T) {
+	r := remote.NewRemote(*newAuthConfig(t, "secret"), actor.NewActorSystem())
+	producer := func() actor.Actor { return &echoActor{} }
+
+	err := r.RegisterKindForNodes("echo", producer, []string{freeAddress(t)})
+	if !errors.Is(err, remote.ErrNodesNotVerified) {
+		t.Fatalf("expected ErrNodesNotVerified, got %v", err)
+	}
+}
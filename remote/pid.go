@@ -0,0 +1,29 @@
+package remote
+
+import (
+	"light-actor-go/actor"
+
+	"github.com/google/uuid"
+)
+
+// Converts PID to proto message, local PID gets address of this node so it can be used from other nodes
+func pidToProto(pid actor.PID, localAddress string) *PID {
+	address := pid.Address
+	if address == "" {
+		address = localAddress
+	}
+	return &PID{Id: pid.ID.String(), Path: pid.Path, Address: address}
+}
+
+// Converts proto message to PID, address is removed from PIDs of this node
+func pidFromProto(pid *PID, localAddress string) (actor.PID, error) {
+	id, err := uuid.Parse(pid.GetId())
+	if err != nil {
+		return actor.PID{}, err
+	}
+	address := pid.GetAddress()
+	if address == localAddress {
+		address = ""
+	}
+	return actor.PID{ID: id, Path: pid.GetPath(), Address: address}, nil
+}
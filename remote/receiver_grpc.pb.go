@@ -20,6 +20,11 @@ const _ = grpc.SupportPackageIsVersion8
 
 const (
 	RemoteReceiver_ReceiveMessage_FullMethodName = "/remote.RemoteReceiver/ReceiveMessage"
+	RemoteReceiver_Stream_FullMethodName         = "/remote.RemoteReceiver/Stream"
+	RemoteReceiver_Resolve_FullMethodName        = "/remote.RemoteReceiver/Resolve"
+	RemoteReceiver_Spawn_FullMethodName          = "/remote.RemoteReceiver/Spawn"
+	RemoteReceiver_List_FullMethodName           = "/remote.RemoteReceiver/List"
+	RemoteReceiver_Unregister_FullMethodName     = "/remote.RemoteReceiver/Unregister"
 )
 
 // RemoteReceiverClient is the client API for RemoteReceiver service.
@@ -27,6 +32,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RemoteReceiverClient interface {
 	ReceiveMessage(ctx context.Context, in *Envelope, opts ...grpc.CallOption) (*Empty, error)
+	// Batches are delivered in order and acknowledged once their envelopes are delivered to actors
+	Stream(ctx context.Context, opts ...grpc.CallOption) (RemoteReceiver_StreamClient, error)
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*PID, error)
+	Spawn(ctx context.Context, in *SpawnRequest, opts ...grpc.CallOption) (*PID, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type remoteReceiverClient struct {
@@ -47,11 +58,89 @@ func (c *remoteReceiverClient) ReceiveMessage(ctx context.Context, in *Envelope,
 	return out, nil
 }
 
+func (c *remoteReceiverClient) Stream(ctx context.Context, opts ...grpc.CallOption) (RemoteReceiver_StreamClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RemoteReceiver_ServiceDesc.Streams[0], RemoteReceiver_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteReceiverStreamClient{ClientStream: stream}
+	return x, nil
+}
+
+type RemoteReceiver_StreamClient interface {
+	Send(*MessageBatch) error
+	Recv() (*BatchAck, error)
+	grpc.ClientStream
+}
+
+type remoteReceiverStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteReceiverStreamClient) Send(m *MessageBatch) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *remoteReceiverStreamClient) Recv() (*BatchAck, error) {
+	m := new(BatchAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteReceiverClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*PID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PID)
+	err := c.cc.Invoke(ctx, RemoteReceiver_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteReceiverClient) Spawn(ctx context.Context, in *SpawnRequest, opts ...grpc.CallOption) (*PID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PID)
+	err := c.cc.Invoke(ctx, RemoteReceiver_Spawn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteReceiverClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, RemoteReceiver_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteReceiverClient) Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, RemoteReceiver_Unregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RemoteReceiverServer is the server API for RemoteReceiver service.
 // All implementations must embed UnimplementedRemoteReceiverServer
 // for forward compatibility
 type RemoteReceiverServer interface {
 	ReceiveMessage(context.Context, *Envelope) (*Empty, error)
+	// Batches are delivered in order and acknowledged once their envelopes are delivered to actors
+	Stream(RemoteReceiver_StreamServer) error
+	Resolve(context.Context, *ResolveRequest) (*PID, error)
+	Spawn(context.Context, *SpawnRequest) (*PID, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	Unregister(context.Context, *UnregisterRequest) (*Empty, error)
 	mustEmbedUnimplementedRemoteReceiverServer()
 }
 
@@ -62,6 +151,21 @@ type UnimplementedRemoteReceiverServer struct {
 func (UnimplementedRemoteReceiverServer) ReceiveMessage(context.Context, *Envelope) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReceiveMessage not implemented")
 }
+func (UnimplementedRemoteReceiverServer) Stream(RemoteReceiver_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedRemoteReceiverServer) Resolve(context.Context, *ResolveRequest) (*PID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedRemoteReceiverServer) Spawn(context.Context, *SpawnRequest) (*PID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Spawn not implemented")
+}
+func (UnimplementedRemoteReceiverServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedRemoteReceiverServer) Unregister(context.Context, *UnregisterRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unregister not implemented")
+}
 func (UnimplementedRemoteReceiverServer) mustEmbedUnimplementedRemoteReceiverServer() {}
 
 // UnsafeRemoteReceiverServer may be embedded to opt out of forward compatibility for this service.
@@ -93,6 +197,104 @@ func _RemoteReceiver_ReceiveMessage_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RemoteReceiver_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RemoteReceiverServer).Stream(&remoteReceiverStreamServer{ServerStream: stream})
+}
+
+type RemoteReceiver_StreamServer interface {
+	Send(*BatchAck) error
+	Recv() (*MessageBatch, error)
+	grpc.ServerStream
+}
+
+type remoteReceiverStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteReceiverStreamServer) Send(m *BatchAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *remoteReceiverStreamServer) Recv() (*MessageBatch, error) {
+	m := new(MessageBatch)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RemoteReceiver_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteReceiverServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteReceiver_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteReceiverServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteReceiver_Spawn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpawnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteReceiverServer).Spawn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteReceiver_Spawn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteReceiverServer).Spawn(ctx, req.(*SpawnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteReceiver_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteReceiverServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteReceiver_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteReceiverServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteReceiver_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteReceiverServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteReceiver_Unregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteReceiverServer).Unregister(ctx, req.(*UnregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RemoteReceiver_ServiceDesc is the grpc.ServiceDesc for RemoteReceiver service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,7 +306,30 @@ var RemoteReceiver_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReceiveMessage",
 			Handler:    _RemoteReceiver_ReceiveMessage_Handler,
 		},
+		{
+			MethodName: "Resolve",
+			Handler:    _RemoteReceiver_Resolve_Handler,
+		},
+		{
+			MethodName: "Spawn",
+			Handler:    _RemoteReceiver_Spawn_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _RemoteReceiver_List_Handler,
+		},
+		{
+			MethodName: "Unregister",
+			Handler:    _RemoteReceiver_Unregister_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _RemoteReceiver_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "receiver.proto",
 }
@@ -2,27 +2,120 @@ package remote
 
 import (
 	"light-actor-go/actor"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
+// ActorInfo describes actor made discoverable under name
+type ActorInfo struct {
+	Name         string
+	PID          actor.PID
+	RegisteredAt time.Time
+	MessageTypes []string // Types actor accepts from other nodes, every type when empty
+}
+
+type registration struct {
+	pid          actor.PID
+	registeredAt time.Time
+	acl          *accessList // Access list of name, name without one accepts every node
+}
+
 type Registry struct {
-	mapping map[string]actor.PID
+	mapping map[string]registration
 	mu      sync.RWMutex
 }
 
 func NewRegistry() *Registry {
-	return &Registry{mapping: make(map[string]actor.PID)}
+	return &Registry{
+		mapping: make(map[string]registration),
+	}
 }
 
 func (r *Registry) Add(name string, actorPID actor.PID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.mapping[name] = actorPID
+	r.mapping[name] = registration{pid: actorPID, registeredAt: time.Now()}
 	return nil
 }
 
-func (r *Registry) Find(name string) actor.PID {
+// Adds actor under name, access list applies to messages sent to name from other nodes
+func (r *Registry) addWithACL(name string, actorPID actor.PID, acl *accessList) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mapping[name] = registration{pid: actorPID, registeredAt: time.Now(), acl: acl}
+}
+
+// Returns PID of actor registered under name, false if there is none
+func (r *Registry) Find(name string) (actor.PID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, exists := r.mapping[name]
+	return reg.pid, exists
+}
+
+// Returns PID and access list of actor registered under name, false if there is none
+func (r *Registry) find(name string) (actor.PID, *accessList, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, exists := r.mapping[name]
+	return reg.pid, reg.acl, exists
+}
+
+// Removes name, returns false if nothing was registered under it
+func (r *Registry) Remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.mapping[name]; !exists {
+		return false
+	}
+	delete(r.mapping, name)
+	return true
+}
+
+// Removes all names of actor, used when actor stops
+func (r *Registry) removeActor(actorPID actor.PID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, reg := range r.mapping {
+		if reg.pid.ID == actorPID.ID {
+			delete(r.mapping, name)
+		}
+	}
+}
+
+// Returns actors registered under names with prefix sorted by name
+func (r *Registry) List(prefix string) []ActorInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]ActorInfo, 0, len(r.mapping))
+	for name, reg := range r.mapping {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		infos = append(infos, ActorInfo{
+			Name:         name,
+			PID:          reg.pid,
+			RegisteredAt: reg.registeredAt,
+			MessageTypes: reg.acl.messageTypes(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// Returns access lists of names actor is registered under, nil for name without one
+func (r *Registry) accessLists(actorPID actor.PID) []*accessList {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.mapping[name]
+	var lists []*accessList
+	for _, reg := range r.mapping {
+		if reg.pid.ID == actorPID.ID {
+			lists = append(lists, reg.acl)
+		}
+	}
+	return lists
 }
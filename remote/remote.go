@@ -1,52 +1,194 @@
 package remote
 
 import (
-	"fmt"
+	"context"
 	"light-actor-go/actor"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Remote struct {
-	remoteReciever RemoteReceiver
+	remoteReciever *RemoteReceiver
 	actorSystem    *actor.ActorSystem
+	endpoints      *endpointManager
+	serializers    *SerializerRegistry
+	requests       *pendingRequests
 }
 
+// Creates remote and enables actor system to send messages to PIDs of other nodes
 func NewRemote(remoteConfing RemoteConfig, actorSystem *actor.ActorSystem) *Remote {
-	return &Remote{remoteReciever: *NewRemoteReceiver(&remoteConfing, actorSystem),
+	serializers := NewSerializerRegistry()
+	requests := newPendingRequests(actorSystem)
+	r := &Remote{remoteReciever: NewRemoteReceiver(&remoteConfing, actorSystem, serializers),
 		actorSystem: actorSystem,
+		endpoints:   newEndpointManager(&remoteConfing, actorSystem, serializers, requests),
+		serializers: serializers,
+		requests:    requests,
 	}
+	r.remoteReciever.endpoints = r.endpoints
+	r.remoteReciever.requests = requests
+	actorSystem.RegisterRemote(remoteConfing.Addr, r)
+	return r
 }
 
-func (r *Remote) Listen() {
-	go r.remoteReciever.startServer()
+// Starts server of this node, returns error if address can not be bound
+func (r *Remote) Start() error {
+	return r.remoteReciever.startServer()
 }
 
-func (r *Remote) SpawnRemoteActor(address string, name string) (actor.PID, error) {
-	newPID, err := actor.NewPID()
-	if err != nil {
-		return newPID, nil
+// Stops accepting envelopes for other nodes and sends queued ones, then stops server and closes
+// connections. Returns ctx error if it is done before that, unsent envelopes go to dead letters
+func (r *Remote) Shutdown(ctx context.Context) error {
+	err := r.endpoints.shutdown(ctx)
+	if stopErr := r.remoteReciever.stopServer(ctx); err == nil {
+		err = stopErr
 	}
+	return err
+}
+
+// Returns registry of message types that can be sent between nodes, types have to be registered
+// on every node before messages of that type are sent
+func (r *Remote) Serializers() *SerializerRegistry {
+	return r.serializers
+}
 
-	remoteSender := NewRemoteSender(address)
-	envelopeChan := make(chan actor.Envelope, 10)
+// Registers kind of actor, other nodes spawn actors of kind with SpawnRemote.
+// Every spawned actor is created by producer and spawned with props
+func (r *Remote) RegisterKind(kind string, producer actor.ActorProducer, props ...actor.ActorProps) error {
+	return r.remoteReciever.AddKind(kind, producer, props...)
+}
 
-	go func() {
-		for {
-			envelope := <-envelopeChan
-			err := remoteSender.SendMessage(envelope.Message, name)
-			if err != nil {
-				fmt.Println(err)
-			}
-		}
-	}()
+// Registers kind of actor that only nodes with given addresses can spawn. Addresses are
+// verified like nodes of ACL, so mutual TLS is required
+func (r *Remote) RegisterKindForNodes(kind string, producer actor.ActorProducer, nodes []string, props ...actor.ActorProps) error {
+	return r.remoteReciever.AddKindForNodes(kind, producer, nodes, props...)
+}
 
-	r.actorSystem.AddRemoteActor(newPID, envelopeChan)
-	return newPID, nil
+// Spawns new actor of kind registered on node with address and returns its PID
+func (r *Remote) SpawnRemote(address string, kind string) (actor.PID, error) {
+	var pid actor.PID
+	err := r.endpoints.call(address, func(sender *RemoteSender) (err error) {
+		pid, err = sender.Spawn(kind, "")
+		return err
+	})
+	return pid, err
+}
+
+// Spawns new actor of kind with name on node with address, name has to be unique on that node
+func (r *Remote) SpawnRemoteNamed(address string, kind string, name string) (actor.PID, error) {
+	var pid actor.PID
+	err := r.endpoints.call(address, func(sender *RemoteSender) (err error) {
+		pid, err = sender.Spawn(kind, name)
+		return err
+	})
+	return pid, err
+}
+
+// Returns PID of actor made discoverable on node with address, no actor is spawned.
+// Messages sent to PID are delivered to that node
+func (r *Remote) SpawnRemoteActor(address string, name string) (actor.PID, error) {
+	return r.Resolve(address, name)
+}
+
+// Returns PID of actor made discoverable under name on node with address
+func (r *Remote) Resolve(address string, name string) (actor.PID, error) {
+	var pid actor.PID
+	err := r.endpoints.call(address, func(sender *RemoteSender) (err error) {
+		pid, err = sender.Resolve(name)
+		return err
+	})
+	return pid, err
+}
+
+// Returns actors made discoverable on node with address under names with prefix,
+// only actors this node is allowed to reach are listed
+func (r *Remote) List(address string, prefix string) ([]ActorInfo, error) {
+	var actors []ActorInfo
+	err := r.endpoints.call(address, func(sender *RemoteSender) (err error) {
+		actors, err = sender.List(prefix)
+		return err
+	})
+	return actors, err
+}
+
+// Removes name of actor made discoverable on node with address, ACL of actor has to allow
+// RemoteUnregister
+func (r *Remote) UnregisterRemote(address string, name string) error {
+	return r.endpoints.call(address, func(sender *RemoteSender) error {
+		return sender.Unregister(name)
+	})
 }
 
 func (r *Remote) MakeActorDiscoverable(actorPID actor.PID, name string) error {
 	return r.remoteReciever.AddRemoteActor(name, actorPID)
 }
 
+// Removes name of actor made discoverable on this node, actor keeps running.
+// Names are removed automatically when actor stops
+func (r *Remote) Unregister(name string) bool {
+	return r.remoteReciever.RemoveRemoteActor(name)
+}
+
+// Returns actors made discoverable on this node under names with prefix
+func (r *Remote) DiscoverableActors(prefix string) []ActorInfo {
+	return r.remoteReciever.RemoteActors(prefix)
+}
+
+// Makes actor discoverable under name, only nodes and message types allowed by acl can reach it
+// through name. Actor reached by PID accepts what any of its names accepts.
+// Message types have to be registered in serializers before
+func (r *Remote) MakeActorDiscoverableWithACL(actorPID actor.PID, name string, acl ACL) error {
+	return r.remoteReciever.AddRemoteActorWithACL(name, actorPID, acl)
+}
+
+// SendRemote queues envelope for node of receiver, envelopes are sent in order per node
+func (r *Remote) SendRemote(envelope actor.Envelope) error {
+	r.endpoints.send(newOutbound(envelope))
+	return nil
+}
+
+// Sends message to actor made discoverable under name on node with address, future completes
+// with reply or with ErrRemoteActorNotFound when there is no such actor. Timeout of zero waits forever,
+// request is dropped once Wait of future gives up
+func (r *Remote) Ask(address string, name string, message interface{}, timeout time.Duration) *actor.Future {
+	future := r.actorSystem.NewFuture(timeout)
+	r.request(future, outboundEnvelope{
+		envelope:     actor.NewEnvelopeWithSender(message, actor.PID{}, future.PID()),
+		address:      address,
+		receiverName: name,
+	})
+	return future
+}
+
+// Sends message to actor on other node, future fails with ErrRemoteActorNotFound when actor does not exist
+func (r *Remote) AskPID(pid actor.PID, message interface{}, timeout time.Duration) *actor.Future {
+	if r.actorSystem.IsLocal(pid) {
+		return r.actorSystem.Ask(pid, message, timeout)
+	}
+	future := r.actorSystem.NewFuture(timeout)
+	r.request(future, newOutbound(actor.NewEnvelopeWithSender(message, pid, future.PID())))
+	return future
+}
+
+func (r *Remote) request(future *actor.Future, outbound outboundEnvelope) {
+	if future.PID().ID == uuid.Nil {
+		return
+	}
+	outbound.correlationID = r.requests.add(future)
+	r.endpoints.send(outbound)
+}
+
+// PIDToProto converts PID to proto message, used for PIDs inside of messages sent to other nodes
+func (r *Remote) PIDToProto(pid actor.PID) *PID {
+	return pidToProto(pid, r.remoteReciever.config.Addr)
+}
+
+// PIDFromProto converts proto message received from other node to PID
+func (r *Remote) PIDFromProto(pid *PID) (actor.PID, error) {
+	return pidFromProto(pid, r.remoteReciever.config.Addr)
+}
+
 // func (r *Remote) findActorName(actorPID actor.PID) string {
 // 	return r.remoteActorRegistry.Find(actorPID)
 // }
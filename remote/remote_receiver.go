@@ -3,15 +3,31 @@ package remote
 import (
 	context "context"
 	"errors"
+	"fmt"
+	"io"
 	"light-actor-go/actor"
 	"log"
 	"net"
+	"sync"
+	"time"
 
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DeadLetterRemoteSendFailed  actor.DeadLetterReason = "remote send failed"
+	DeadLetterUnknownRemoteName actor.DeadLetterReason = "unknown remote name"
+	DeadLetterReplyNotSent      actor.DeadLetterReason = "reply sender is not on calling node"
 )
 
 type RemoteConfig struct {
-	Addr string
+	Addr        string
+	TLS         *TLSConfig    // Connections are not encrypted when nil
+	IdleTimeout time.Duration // Connections to nodes not used for this long are closed, 5 minutes when zero
+	AuthSecret  []byte        // Shared by all nodes, calls without valid token signed by it are rejected when set
 }
 
 type RemoteReceiver struct {
@@ -19,34 +35,85 @@ type RemoteReceiver struct {
 	actorSystem        *actor.ActorSystem
 	server             *grpc.Server
 	config             *RemoteConfig
-	localActorRegistry Registry //Registy of local actors that are discoverable remotely
+	serializers        *SerializerRegistry
+	localActorRegistry *Registry           // Registry of local actors that are discoverable remotely
+	stoppedSub         *actor.Subscription // Removes names of actors that stop
+	kinds              *kindRegistry       // Kinds of actors other nodes can spawn on this node
+	stopping           chan struct{}       // Closed on shutdown, ends streams opened by other nodes
+	stopOnce           sync.Once
+	configErr          error            // Invalid config, returned when server is started
+	endpoints          *endpointManager // Sends error replies to requests that could not be delivered
+	requests           *pendingRequests // Requests sent by this node, failed by error replies
 }
 
 func NewRemoteConfig(addr string) *RemoteConfig {
 	return &RemoteConfig{Addr: addr}
 }
 
-func NewRemoteReceiver(config *RemoteConfig, actorSystem *actor.ActorSystem) *RemoteReceiver {
+func NewRemoteReceiver(config *RemoteConfig, actorSystem *actor.ActorSystem, serializers *SerializerRegistry) *RemoteReceiver {
 	receiver := &RemoteReceiver{
 		config:             config,
 		actorSystem:        actorSystem,
-		localActorRegistry: *NewRegistry(),
+		serializers:        serializers,
+		localActorRegistry: NewRegistry(),
+		kinds:              newKindRegistry(),
+		stopping:           make(chan struct{}),
+	}
+	creds, err := config.serverCredentials()
+	if err != nil {
+		receiver.configErr = err
+		creds = insecure.NewCredentials()
 	}
+	receiver.server = grpc.NewServer(grpc.Creds(creds))
+	RegisterRemoteReceiverServer(receiver.server, receiver)
+
+	receiver.stoppedSub = actorSystem.EventStream().Subscribe(func(event interface{}) {
+		receiver.localActorRegistry.removeActor(event.(actor.ActorStopped).Who)
+	}, func(event interface{}) bool {
+		_, ok := event.(actor.ActorStopped)
+		return ok
+	})
 
 	return receiver
 }
 
-// startServer starts the gRPC server and listens for incoming messages
-func (r *RemoteReceiver) startServer() {
+// startServer listens on configured address and serves incoming messages in background
+func (r *RemoteReceiver) startServer() error {
+	if r.configErr != nil {
+		return fmt.Errorf("invalid remote config: %w", r.configErr)
+	}
 	lis, err := net.Listen("tcp", r.config.Addr)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		return fmt.Errorf("failed to listen on %s: %w", r.config.Addr, err)
 	}
-	r.server = grpc.NewServer()
-	RegisterRemoteReceiverServer(r.server, r)
 	log.Printf("server listening at %v", lis.Addr())
-	if err := r.server.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	go func() {
+		if err := r.server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			log.Printf("[Remote] server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Ends streams opened by other nodes, stops accepting connections and waits for pending calls
+// to finish. When ctx is done before that, connections are closed forcibly
+func (r *RemoteReceiver) stopServer(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		close(r.stopping)
+		r.actorSystem.EventStream().Unsubscribe(r.stoppedSub)
+	})
+	stopped := make(chan struct{})
+	go func() {
+		r.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		r.server.Stop()
+		<-stopped
+		return ctx.Err()
 	}
 }
 
@@ -54,12 +121,292 @@ func (r *RemoteReceiver) AddRemoteActor(name string, actorPID actor.PID) error {
 	return r.localActorRegistry.Add(name, actorPID)
 }
 
+// Removes name, returns false if nothing was registered under it
+func (r *RemoteReceiver) RemoveRemoteActor(name string) bool {
+	return r.localActorRegistry.Remove(name)
+}
+
+// Returns actors made discoverable under names with prefix
+func (r *RemoteReceiver) RemoteActors(prefix string) []ActorInfo {
+	return r.localActorRegistry.List(prefix)
+}
+
+// Registers kind of actor that other nodes can spawn on this node
+func (r *RemoteReceiver) AddKind(kind string, producer actor.ActorProducer, props ...actor.ActorProps) error {
+	return r.kinds.add(kind, producer, props, nil)
+}
+
+// Registers kind of actor that only nodes with given addresses can spawn on this node
+func (r *RemoteReceiver) AddKindForNodes(kind string, producer actor.ActorProducer, nodes []string, props ...actor.ActorProps) error {
+	list, err := r.newAccessList(ACL{Nodes: nodes})
+	if err != nil {
+		return err
+	}
+	return r.kinds.add(kind, producer, props, list)
+}
+
+// Adds actor under name, messages to name from nodes or of types not allowed by acl are rejected
+func (r *RemoteReceiver) AddRemoteActorWithACL(name string, actorPID actor.PID, acl ACL) error {
+	list, err := r.newAccessList(acl)
+	if err != nil {
+		return err
+	}
+	r.localActorRegistry.addWithACL(name, actorPID, list)
+	return nil
+}
+
+// Node addresses claimed by callers are only verified by client certificates, so ACL with nodes
+// is rejected without mutual TLS
+func (r *RemoteReceiver) newAccessList(acl ACL) (*accessList, error) {
+	if len(acl.Nodes) > 0 && !r.config.verifiesNodes() {
+		return nil, ErrNodesNotVerified
+	}
+	return newAccessList(acl, r.serializers)
+}
+
 func (r *RemoteReceiver) ReceiveMessage(context context.Context, envelope *Envelope) (*Empty, error) {
-	actorPID := r.localActorRegistry.Find(envelope.Receiver)
-	if (actorPID == actor.PID{}) {
-		return &Empty{}, errors.New("no actor with name " + envelope.Receiver + " exists")
+	node, err := authenticate(context, r.config)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.deliver(envelope, node); err != nil {
+		return &Empty{}, err
+	}
+	return &Empty{}, nil
+}
+
+// Delivers batches in order they are received, batch is acknowledged after its envelopes are
+// passed to actors so sender slows down when actors do not keep up
+func (r *RemoteReceiver) Stream(stream RemoteReceiver_StreamServer) error {
+	node, err := authenticate(stream.Context(), r.config)
+	if err != nil {
+		return err
+	}
+
+	batches := make(chan *MessageBatch)
+	received := make(chan error, 1)
+	go func() {
+		for {
+			batch, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			select {
+			case batches <- batch:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case batch := <-batches:
+			for _, envelope := range batch.Envelopes {
+				if err := r.deliver(envelope, node); err != nil {
+					log.Printf("[Remote] dropped envelope: %v", err)
+				}
+			}
+			if err := stream.Send(&BatchAck{Id: batch.Id}); err != nil {
+				return err
+			}
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-r.stopping:
+			// batches that were not acknowledged are sent again by other node
+			return status.Error(codes.Unavailable, "remote is shutting down")
+		}
+	}
+}
+
+// Delivers envelope sent by node to local actor if its access list allows it. Request that
+// can not be delivered is answered with error reply
+func (r *RemoteReceiver) deliver(envelope *Envelope, node string) error {
+	if envelope.Error != nil {
+		if r.requests != nil {
+			r.requests.fail(envelope.CorrelationId, envelope.Error.toError())
+		}
+		return nil
+	}
+
+	err := r.deliverMessage(envelope, node)
+	if err != nil && envelope.CorrelationId != 0 && envelope.Sender != nil && r.endpoints != nil {
+		r.replyError(envelope, node, err)
+	}
+	return err
+}
+
+// Sends error reply to sender of request. Sender is chosen by calling node, so reply is only
+// sent when sender is on that node, otherwise it would be sent to any node caller names
+func (r *RemoteReceiver) replyError(envelope *Envelope, node string, err error) {
+	senderPID, pidErr := pidFromProto(envelope.Sender, r.config.Addr)
+	if pidErr != nil {
+		return
+	}
+	if node == "" || senderPID.Address != node {
+		r.actorSystem.SendToDeadLetters(actor.NewEnvelope(err, senderPID), DeadLetterReplyNotSent)
+		return
+	}
+	r.endpoints.send(outboundEnvelope{
+		envelope:      actor.NewEnvelope(nil, senderPID),
+		address:       senderPID.Address,
+		correlationID: envelope.CorrelationId,
+		err:           newRequestError(err),
+	})
+}
+
+func (r *RemoteReceiver) deliverMessage(envelope *Envelope, node string) error {
+	var actorPID actor.PID
+	var acls []*accessList
+	if envelope.Receiver == nil && envelope.ReceiverName != "" {
+		var acl *accessList
+		var exists bool
+		actorPID, acl, exists = r.localActorRegistry.find(envelope.ReceiverName)
+		if !exists {
+			r.deadLetterUnknownName(envelope)
+			return status.Errorf(codes.NotFound, "no actor with name %s exists", envelope.ReceiverName)
+		}
+		acls = []*accessList{acl}
+	} else {
+		var err error
+		actorPID, err = pidFromProto(envelope.GetReceiver(), r.config.Addr)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid receiver id "+envelope.GetReceiver().GetId())
+		}
+		// forwarding would send message to other node with identity of this node
+		if actorPID.Address != "" {
+			return status.Errorf(codes.InvalidArgument, "receiver %v is not on this node", actorPID)
+		}
+		// actor reached by PID accepts what any of its names accepts
+		acls = r.localActorRegistry.accessLists(actorPID)
+	}
+
+	if !allowsMessage(acls, node, envelope.TypeName) {
+		return status.Errorf(codes.PermissionDenied, "node %s can not send %s to %v", node, envelope.TypeName, actorPID)
+	}
+	if envelope.CorrelationId != 0 && r.actorSystem.Registry().Find(actorPID) == nil {
+		return status.Errorf(codes.NotFound, "actor %v does not exist", actorPID)
+	}
+
+	message, err := r.serializers.Deserialize(envelope.TypeName, envelope.Message)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "message for %v: %v", actorPID, err)
+	}
+
+	actorEnvelope := actor.NewEnvelope(message, actorPID)
+	if envelope.Sender != nil {
+		senderPID, err := pidFromProto(envelope.Sender, r.config.Addr)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid sender id "+envelope.Sender.GetId())
+		}
+		actorEnvelope = actor.NewEnvelopeWithSender(message, actorPID, senderPID)
 	}
-	actorEnvelope := actor.NewEnvelope(envelope.Message, actorPID)
 	r.actorSystem.Send(actorEnvelope)
+	return nil
+}
+
+// Returns PID of actor made discoverable under name
+func (r *RemoteReceiver) Resolve(context context.Context, request *ResolveRequest) (*PID, error) {
+	node, err := authenticate(context, r.config)
+	if err != nil {
+		return nil, err
+	}
+	actorPID, acl, exists := r.localActorRegistry.find(request.Name)
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no actor with name %s exists", request.Name)
+	}
+	if !acl.allowsNode(node) {
+		return nil, status.Errorf(codes.PermissionDenied, "node %s can not resolve %s", node, request.Name)
+	}
+	return pidToProto(actorPID, r.config.Addr), nil
+}
+
+// Spawns actor of registered kind and returns its PID
+func (r *RemoteReceiver) Spawn(context context.Context, request *SpawnRequest) (*PID, error) {
+	node, err := authenticate(context, r.config)
+	if err != nil {
+		return nil, err
+	}
+	kind, exists := r.kinds.find(request.Kind)
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no actor kind %s is registered", request.Kind)
+	}
+	if !kind.access.allowsNode(node) {
+		return nil, status.Errorf(codes.PermissionDenied, "node %s can not spawn %s", node, request.Kind)
+	}
+
+	var actorPID actor.PID
+	if request.Name == "" {
+		actorPID, err = r.actorSystem.SpawnActor(kind.producer(), kind.props...)
+	} else {
+		actorPID, err = r.actorSystem.SpawnNamed(request.Name, kind.producer(), kind.props...)
+	}
+	switch {
+	case errors.Is(err, actor.ErrNameExists):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, actor.ErrInvalidName):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return pidToProto(actorPID, r.config.Addr), nil
+}
+
+// Publishes envelope sent to name that is not registered as dead letter,
+// message is left serialized if it can not be deserialized
+func (r *RemoteReceiver) deadLetterUnknownName(envelope *Envelope) {
+	var message interface{} = envelope.Message
+	if deserialized, err := r.serializers.Deserialize(envelope.TypeName, envelope.Message); err == nil {
+		message = deserialized
+	}
+	receiver := actor.PID{Path: "/" + envelope.ReceiverName, Address: r.config.Addr}
+	actorEnvelope := actor.NewEnvelope(message, receiver)
+	if envelope.Sender != nil {
+		if senderPID, err := pidFromProto(envelope.Sender, r.config.Addr); err == nil {
+			actorEnvelope = actor.NewEnvelopeWithSender(message, receiver, senderPID)
+		}
+	}
+	r.actorSystem.SendToDeadLetters(actorEnvelope, DeadLetterUnknownRemoteName)
+}
+
+// Lists discoverable actors calling node is allowed to resolve
+func (r *RemoteReceiver) List(context context.Context, request *ListRequest) (*ListResponse, error) {
+	node, err := authenticate(context, r.config)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListResponse{}
+	for _, info := range r.localActorRegistry.List(request.Prefix) {
+		if _, acl, exists := r.localActorRegistry.find(info.Name); !exists || !acl.allowsNode(node) {
+			continue
+		}
+		response.Actors = append(response.Actors, &DiscoverableActor{
+			Name:         info.Name,
+			Pid:          pidToProto(info.PID, r.config.Addr),
+			RegisteredAt: info.RegisteredAt.UnixMilli(),
+			MessageTypes: info.MessageTypes,
+		})
+	}
+	return response, nil
+}
+
+// Removes name of discoverable actor with ACL that allows remote unregister, actor keeps running
+func (r *RemoteReceiver) Unregister(context context.Context, request *UnregisterRequest) (*Empty, error) {
+	node, err := authenticate(context, r.config)
+	if err != nil {
+		return nil, err
+	}
+	_, acl, exists := r.localActorRegistry.find(request.Name)
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no actor with name %s exists", request.Name)
+	}
+	if !acl.allowsUnregister(node) {
+		return nil, status.Errorf(codes.PermissionDenied, "node %s can not unregister %s", node, request.Name)
+	}
+	r.localActorRegistry.Remove(request.Name)
 	return &Empty{}, nil
 }
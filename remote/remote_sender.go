@@ -2,45 +2,180 @@ package remote
 
 import (
 	"context"
+	"light-actor-go/actor"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/proto"
-	anypb "google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/grpc/credentials"
 )
 
+// RemoteSender keeps connection to remote node, connection is created on first use
 type RemoteSender struct {
 	remoteAddress string
+	localAddress  string // Address of this node, sent along with sender so replies can be routed back
+	serializers   *SerializerRegistry
+	credentials   credentials.TransportCredentials
+	auth          nodeCredentials
+	conn          *grpc.ClientConn
+	client        RemoteReceiverClient
+	mu            sync.Mutex
 }
 
-func NewRemoteSender(address string) *RemoteSender {
+// Creates sender to node with address, config is config of this node
+func NewRemoteSender(address string, config *RemoteConfig, serializers *SerializerRegistry) *RemoteSender {
 	return &RemoteSender{
 		remoteAddress: address,
+		localAddress:  config.Addr,
+		serializers:   serializers,
+		credentials:   config.clientCredentials(),
+		auth:          nodeCredentials{address: config.Addr, secret: config.AuthSecret},
 	}
 }
 
-func (rs *RemoteSender) SendMessage(message interface{}, receiverName string) error {
-	conn, err := grpc.NewClient(rs.remoteAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// Returns client of current connection, connecting if there is none
+func (rs *RemoteSender) connect() (RemoteReceiverClient, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.client != nil {
+		return rs.client, nil
+	}
+	conn, err := grpc.NewClient(rs.remoteAddress,
+		grpc.WithTransportCredentials(rs.credentials),
+		grpc.WithPerRPCCredentials(rs.auth),
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer conn.Close()
+	rs.conn = conn
+	rs.client = NewRemoteReceiverClient(conn)
+	return rs.client, nil
+}
+
+// Closes current connection, next send creates new one
+func (rs *RemoteSender) reconnect() {
+	rs.Close()
+}
 
-	client := NewRemoteReceiverClient(conn)
+func (rs *RemoteSender) Close() error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.conn == nil {
+		return nil
+	}
+	err := rs.conn.Close()
+	rs.conn = nil
+	rs.client = nil
+	return err
+}
 
-	anyMsg, err := anypb.New(message.(proto.Message))
+// Sends envelope to actor on remote node with single call, message has to be registered in serializers.
+// Endpoints send envelopes in batches over stream, see openStream
+func (rs *RemoteSender) SendMessage(envelope actor.Envelope) error {
+	protoEnvelope, err := rs.toProto(newOutbound(envelope))
+	if err != nil {
+		return err
+	}
+	client, err := rs.connect()
 	if err != nil {
 		return err
 	}
+	_, err = client.ReceiveMessage(context.Background(), protoEnvelope)
+	return err
+}
+
+// Opens stream that carries batches of envelopes, batches are delivered in order they are sent.
+// Stream ends when ctx is cancelled
+func (rs *RemoteSender) openStream(ctx context.Context) (*batchStream, error) {
+	client, err := rs.connect()
+	if err != nil {
+		return nil, err
+	}
+	return openBatchStream(ctx, client)
+}
 
+func (rs *RemoteSender) toProto(outbound outboundEnvelope) (*Envelope, error) {
+	envelope := outbound.envelope
 	protoEnvelope := &Envelope{
-		Message:  anyMsg,
-		Receiver: receiverName,
+		CorrelationId: outbound.correlationID,
+		ReceiverName:  outbound.receiverName,
+		Error:         outbound.err,
+	}
+	if outbound.err == nil {
+		typeName, message, err := rs.serializers.Serialize(envelope.Message)
+		if err != nil {
+			return nil, err
+		}
+		protoEnvelope.TypeName = typeName
+		protoEnvelope.Message = message
+	}
+	if receiver := envelope.Receiver(); receiver != nil {
+		protoEnvelope.Receiver = pidToProto(*receiver, rs.localAddress)
+	}
+	if sender := envelope.Sender(); sender != nil {
+		protoEnvelope.Sender = pidToProto(*sender, rs.localAddress)
+	}
+	return protoEnvelope, nil
+}
+
+// Returns PID of actor made discoverable on remote node under name
+func (rs *RemoteSender) Resolve(name string) (actor.PID, error) {
+	client, err := rs.connect()
+	if err != nil {
+		return actor.PID{}, err
+	}
+	pid, err := client.Resolve(context.Background(), &ResolveRequest{Name: name})
+	if err != nil {
+		return actor.PID{}, err
 	}
+	return pidFromProto(pid, rs.localAddress)
+}
 
-	_, err = client.ReceiveMessage(context.Background(), protoEnvelope)
+// Spawns actor of kind registered on remote node, name is generated when it is empty
+func (rs *RemoteSender) Spawn(kind string, name string) (actor.PID, error) {
+	client, err := rs.connect()
+	if err != nil {
+		return actor.PID{}, err
+	}
+	pid, err := client.Spawn(context.Background(), &SpawnRequest{Kind: kind, Name: name})
+	if err != nil {
+		return actor.PID{}, err
+	}
+	return pidFromProto(pid, rs.localAddress)
+}
+
+// Returns actors made discoverable on remote node under names with prefix
+func (rs *RemoteSender) List(prefix string) ([]ActorInfo, error) {
+	client, err := rs.connect()
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.List(context.Background(), &ListRequest{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]ActorInfo, 0, len(response.Actors))
+	for _, discoverable := range response.Actors {
+		pid, err := pidFromProto(discoverable.Pid, rs.localAddress)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, ActorInfo{
+			Name:         discoverable.Name,
+			PID:          pid,
+			RegisteredAt: time.UnixMilli(discoverable.RegisteredAt),
+			MessageTypes: discoverable.MessageTypes,
+		})
+	}
+	return infos, nil
+}
+
+// Removes name of discoverable actor on remote node
+func (rs *RemoteSender) Unregister(name string) error {
+	client, err := rs.connect()
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = client.Unregister(context.Background(), &UnregisterRequest{Name: name})
+	return err
 }
@@ -0,0 +1,127 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"testing"
+	"time"
+)
+
+func TestShutdownRejectsNewEndpoints(t *testing.T) {
+	address, _ := startReceiverNode(t, nil)
+	sender, system := startSenderNode(t, nil)
+	deadLetters := make(chan actor.DeadLetter, 1)
+	system.DeadLetters().Subscribe(func(deadLetter actor.DeadLetter) {
+		if deadLetter.Reason == remote.DeadLetterRemoteSendFailed {
+			deadLetters <- deadLetter
+		}
+	})
+	if err := sender.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if err := sender.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shut down: %v", err)
+	}
+
+	if _, err := sender.Resolve(address, "receiver"); !errors.Is(err, remote.ErrRemoteStopped) {
+		t.Fatalf("expected ErrRemoteStopped from resolve, got %v", err)
+	}
+	if _, err := sender.List(address, ""); !errors.Is(err, remote.ErrRemoteStopped) {
+		t.Fatalf("expected ErrRemoteStopped from list, got %v", err)
+	}
+	pid, err := actor.NewPID()
+	if err != nil {
+		t.Fatalf("failed to create pid: %v", err)
+	}
+	pid.Address = address
+	system.Send(actor.NewEnvelope(Ping{Value: "ping"}, pid))
+	select {
+	case <-deadLetters:
+	case <-time.After(5 * time.Second):
+		t.Fatal("envelope sent after shutdown was not published as dead letter")
+	}
+}
+
+// blockedActor does not process messages after first ping until release is closed
+type blockedActor struct {
+	received chan struct{}
+	release  chan struct{}
+}
+
+func (a *blockedActor) Receive(ctx actor.ActorContext) {
+	if _, ok := ctx.Message().(Ping); ok {
+		select {
+		case a.received <- struct{}{}:
+		default:
+		}
+		<-a.release
+	}
+}
+
+func TestShutdownStopsWhenContextExpires(t *testing.T) {
+	config := newNodeConfig(t, nil)
+	system := actor.NewActorSystem()
+	receiver := remote.NewRemote(*config, system)
+	receiver.Serializers().Register(Ping{}, remote.JSONSerializer)
+	if err := receiver.Start(); err != nil {
+		t.Fatalf("failed to start receiver node: %v", err)
+	}
+	t.Cleanup(func() { receiver.Shutdown(context.Background()) })
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	props := actor.NewActorProps(nil)
+	props.SetBoundedMailbox(1, actor.OverflowBlock)
+	received := make(chan struct{}, 1)
+	pid, err := system.SpawnActor(&blockedActor{received: received, release: release}, *props)
+	if err != nil {
+		t.Fatalf("failed to spawn actor: %v", err)
+	}
+	if err := receiver.MakeActorDiscoverable(pid, "blocked"); err != nil {
+		t.Fatalf("failed to make actor discoverable: %v", err)
+	}
+
+	sender, senderSystem := startSenderNode(t, nil)
+	remotePID, err := sender.SpawnRemoteActor(config.Addr, "blocked")
+	if err != nil {
+		t.Fatalf("failed to resolve actor: %v", err)
+	}
+	go func() {
+		for i := 0; i < 3000; i++ {
+			senderSystem.Send(actor.NewEnvelope(Ping{Value: "ping"}, remotePID))
+		}
+	}()
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first ping was not delivered")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() { stopped <- sender.Shutdown(ctx) }()
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not return after its context expired")
+	}
+}
+
+func TestIdleEndpointReconnects(t *testing.T) {
+	address, received := startReceiverNode(t, nil)
+	config := newNodeConfig(t, nil)
+	config.IdleTimeout = 10 * time.Millisecond
+	sender, system := startSenderNodeWithConfig(t, config)
+
+	// endpoint is evicted between pings and messages are sent over new one
+	for i := 0; i < 10; i++ {
+		sendPing(t, address, sender, system, received)
+		time.Sleep(3 * config.IdleTimeout)
+	}
+}
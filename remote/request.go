@@ -0,0 +1,84 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"light-actor-go/actor"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrRemoteActorNotFound = errors.New("remote actor not found")
+
+// outboundEnvelope is envelope queued for other node
+type outboundEnvelope struct {
+	envelope      actor.Envelope
+	address       string // Node envelope is sent to
+	receiverName  string // Name of discoverable actor, used when envelope has no receiver
+	correlationID uint64 // Set on requests and on error replies to them
+	err           *RequestError
+}
+
+func newOutbound(envelope actor.Envelope) outboundEnvelope {
+	return outboundEnvelope{envelope: envelope, address: envelope.Receiver().Address}
+}
+
+func newRequestError(err error) *RequestError {
+	return &RequestError{Code: int32(status.Code(err)), Message: status.Convert(err).Message()}
+}
+
+// Converts error received from other node, unknown receiver is reported as ErrRemoteActorNotFound
+func (e *RequestError) toError() error {
+	code := codes.Code(e.Code)
+	if code == codes.NotFound {
+		return fmt.Errorf("%w: %s", ErrRemoteActorNotFound, e.Message)
+	}
+	return status.Error(code, e.Message)
+}
+
+// pendingRequests keeps futures of requests sent to other nodes by correlation ID, request is
+// removed when its future completes with reply, error, timeout or because caller stopped waiting
+type pendingRequests struct {
+	actorSystem *actor.ActorSystem
+	nextID      atomic.Uint64
+	futures     map[uint64]*actor.Future
+	mu          sync.Mutex
+}
+
+func newPendingRequests(actorSystem *actor.ActorSystem) *pendingRequests {
+	return &pendingRequests{
+		actorSystem: actorSystem,
+		futures:     make(map[uint64]*actor.Future),
+	}
+}
+
+// Returns correlation ID of request completed by future
+func (pr *pendingRequests) add(future *actor.Future) uint64 {
+	id := pr.nextID.Add(1)
+	pr.mu.Lock()
+	pr.futures[id] = future
+	pr.mu.Unlock()
+
+	go func() {
+		<-future.Done()
+		pr.mu.Lock()
+		delete(pr.futures, id)
+		pr.mu.Unlock()
+	}()
+	return id
+}
+
+// Completes future of request with err, request that already completed is ignored
+func (pr *pendingRequests) fail(id uint64, err error) {
+	pr.mu.Lock()
+	future, exists := pr.futures[id]
+	delete(pr.futures, id)
+	pr.mu.Unlock()
+	if !exists {
+		return
+	}
+	pr.actorSystem.Send(actor.NewEnvelope(actor.ReplyError{Err: err}, future.PID()))
+}
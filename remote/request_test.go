@@ -0,0 +1,227 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// echoActor replies to ping with pong carrying same value, it does not reply to pong
+type echoActor struct{}
+
+func (a *echoActor) Receive(ctx actor.ActorContext) {
+	if msg, ok := ctx.Message().(Ping); ok {
+		ctx.Respond(Pong{Value: msg.Value})
+	}
+}
+
+// Starts node with echo actor discoverable under name echo, returns address of node and PID of actor
+func startEchoNode(t *testing.T, acl *remote.ACL) (string, actor.PID, *actor.ActorSystem) {
+	t.Helper()
+	config := newNodeConfig(t, nil)
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	r.Serializers().Register(Pong{}, remote.JSONSerializer)
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start echo node: %v", err)
+	}
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+
+	pid, err := system.SpawnActor(&echoActor{})
+	if err != nil {
+		t.Fatalf("failed to spawn echo actor: %v", err)
+	}
+	if acl != nil {
+		err = r.MakeActorDiscoverableWithACL(pid, "echo", *acl)
+	} else {
+		err = r.MakeActorDiscoverable(pid, "echo")
+	}
+	if err != nil {
+		t.Fatalf("failed to make echo actor discoverable: %v", err)
+	}
+	return config.Addr, pid, system
+}
+
+// Starts node that asks, node has to listen to receive replies
+func startAskingNode(t *testing.T) *remote.Remote {
+	t.Helper()
+	r, _ := startSenderNodeWithConfig(t, newNodeConfig(t, nil))
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start asking node: %v", err)
+	}
+	return r
+}
+
+func resolve(t *testing.T, r *remote.Remote, address string, name string) actor.PID {
+	t.Helper()
+	pid, err := r.SpawnRemoteActor(address, name)
+	if err != nil {
+		t.Fatalf("failed to resolve %s: %v", name, err)
+	}
+	return pid
+}
+
+func TestAsk(t *testing.T) {
+	address, _, _ := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+
+	result, err := sender.Ask(address, "echo", Ping{Value: "hello"}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("ask failed: %v", err)
+	}
+	if pong, ok := result.(Pong); !ok || pong.Value != "hello" {
+		t.Fatalf("received %#v, expected pong with hello", result)
+	}
+}
+
+func TestAskWithoutTimeout(t *testing.T) {
+	address, _, _ := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := sender.Ask(address, "echo", Ping{Value: "hello"}, 0).Wait(ctx); err != nil {
+		t.Fatalf("ask failed: %v", err)
+	}
+	if _, err := sender.Ask(address, "missing", Ping{Value: "hello"}, 0).Wait(ctx); !errors.Is(err, remote.ErrRemoteActorNotFound) {
+		t.Fatalf("expected ErrRemoteActorNotFound, got %v", err)
+	}
+}
+
+func TestAskConcurrentRequests(t *testing.T) {
+	address, _, _ := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+	pid := resolve(t, sender, address, "echo")
+
+	futures := make([]*actor.Future, 100)
+	for i := range futures {
+		futures[i] = sender.AskPID(pid, Ping{Value: strconv.Itoa(i)}, 5*time.Second)
+	}
+	for i, future := range futures {
+		result, err := future.Result()
+		if err != nil {
+			t.Fatalf("ask %d failed: %v", i, err)
+		}
+		if expected := strconv.Itoa(i); result.(Pong).Value != expected {
+			t.Fatalf("ask %d received %v, expected %s", i, result, expected)
+		}
+	}
+}
+
+func TestAskUnknownName(t *testing.T) {
+	address, _, system := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+	deadLetters := make(chan actor.DeadLetter, 1)
+	system.DeadLetters().Subscribe(func(deadLetter actor.DeadLetter) {
+		if deadLetter.Reason == remote.DeadLetterUnknownRemoteName {
+			deadLetters <- deadLetter
+		}
+	})
+
+	_, err := sender.Ask(address, "missing", Ping{Value: "hello"}, 5*time.Second).Result()
+	if !errors.Is(err, remote.ErrRemoteActorNotFound) {
+		t.Fatalf("expected ErrRemoteActorNotFound, got %v", err)
+	}
+	select {
+	case deadLetter := <-deadLetters:
+		if deadLetter.Message != (Ping{Value: "hello"}) || deadLetter.Receiver.Path != "/missing" {
+			t.Fatalf("unexpected dead letter %+v", deadLetter)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message to unknown name was not published as dead letter")
+	}
+}
+
+func TestAskStoppedActor(t *testing.T) {
+	address, localPID, system := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+	pid := resolve(t, sender, address, "echo")
+
+	stopped := make(chan struct{}, 1)
+	system.EventStream().Subscribe(func(event interface{}) {
+		stopped <- struct{}{}
+	}, func(event interface{}) bool {
+		e, ok := event.(actor.ActorStopped)
+		return ok && e.Who == localPID
+	})
+	system.Stop(localPID)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("echo actor was not stopped")
+	}
+
+	_, err := sender.AskPID(pid, Ping{Value: "hello"}, 5*time.Second).Result()
+	if !errors.Is(err, remote.ErrRemoteActorNotFound) {
+		t.Fatalf("expected ErrRemoteActorNotFound, got %v", err)
+	}
+}
+
+func TestAskRejectedByACL(t *testing.T) {
+	address, _, _ := startEchoNode(t, &remote.ACL{Messages: []interface{}{Pong{}}})
+	sender := startAskingNode(t)
+
+	_, err := sender.Ask(address, "echo", Ping{Value: "hello"}, 5*time.Second).Result()
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestAskTimeout(t *testing.T) {
+	address, _, _ := startEchoNode(t, nil)
+	sender := startAskingNode(t)
+
+	_, err := sender.Ask(address, "echo", Pong{Value: "hello"}, 100*time.Millisecond).Result()
+	if !errors.Is(err, actor.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestAskErrorReplyOnlyToCallingNode(t *testing.T) {
+	address, _, system := startEchoNode(t, nil)
+	deadLetters := make(chan actor.DeadLetter, 1)
+	system.DeadLetters().Subscribe(func(deadLetter actor.DeadLetter) {
+		if deadLetter.Reason == remote.DeadLetterReplyNotSent {
+			deadLetters <- deadLetter
+		}
+	})
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect to echo node: %v", err)
+	}
+	defer conn.Close()
+	sender, err := actor.NewPID()
+	if err != nil {
+		t.Fatalf("failed to create pid: %v", err)
+	}
+	// caller names actor on other node as sender, error reply would be sent to that node
+	envelope := &remote.Envelope{
+		ReceiverName:  "missing",
+		Sender:        &remote.PID{Id: sender.ID.String(), Address: freeAddress(t)},
+		CorrelationId: 1,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := remote.NewRemoteReceiverClient(conn).ReceiveMessage(ctx, envelope); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	select {
+	case deadLetter := <-deadLetters:
+		if deadLetter.Receiver.ID != sender.ID {
+			t.Fatalf("unexpected dead letter %+v", deadLetter)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("error reply to other node was not published as dead letter")
+	}
+}
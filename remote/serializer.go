@@ -0,0 +1,202 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	ErrUnknownMessageType = errors.New("message type is not registered")
+	ErrTypeRegistered     = errors.New("message type is already registered")
+)
+
+// Serializer converts messages sent to other nodes to bytes and back
+type Serializer interface {
+	Serialize(message interface{}) ([]byte, error)
+	// Deserialize fills message, which is pointer to new value of registered type
+	Deserialize(data []byte, message interface{}) error
+}
+
+var (
+	ProtoSerializer Serializer = protoSerializer{}
+	JSONSerializer  Serializer = jsonSerializer{}
+	GobSerializer   Serializer = gobSerializer{}
+)
+
+type protoSerializer struct{}
+
+func (protoSerializer) Serialize(message interface{}) ([]byte, error) {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("message %T is not proto message", message)
+	}
+	return proto.Marshal(protoMessage)
+}
+
+func (protoSerializer) Deserialize(data []byte, message interface{}) error {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return fmt.Errorf("message %T is not proto message", message)
+	}
+	return proto.Unmarshal(data, protoMessage)
+}
+
+type jsonSerializer struct{}
+
+func (jsonSerializer) Serialize(message interface{}) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+func (jsonSerializer) Deserialize(data []byte, message interface{}) error {
+	return json.Unmarshal(data, message)
+}
+
+type gobSerializer struct{}
+
+func (gobSerializer) Serialize(message interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(message); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobSerializer) Deserialize(data []byte, message interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(message)
+}
+
+type messageType struct {
+	name       string
+	typ        reflect.Type
+	serializer Serializer
+}
+
+// SerializerRegistry keeps serializer for every message type sent between nodes, type is sent
+// by name so message has to be registered under same name on both nodes. Proto messages
+// that are not registered are serialized with ProtoSerializer under their full proto name
+type SerializerRegistry struct {
+	byName map[string]*messageType
+	byType map[reflect.Type]*messageType
+	mu     sync.RWMutex
+}
+
+func NewSerializerRegistry() *SerializerRegistry {
+	return &SerializerRegistry{
+		byName: make(map[string]*messageType),
+		byType: make(map[reflect.Type]*messageType),
+	}
+}
+
+// Registers type of message under its package path and type name
+func (sr *SerializerRegistry) Register(message interface{}, serializer Serializer) error {
+	return sr.RegisterName(typeName(reflect.TypeOf(message)), message, serializer)
+}
+
+// Registers type of message under name, message can be value or pointer and actor receives same kind
+func (sr *SerializerRegistry) RegisterName(name string, message interface{}, serializer Serializer) error {
+	typ := reflect.TypeOf(message)
+	if typ == nil {
+		return fmt.Errorf("can not register nil message")
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if _, exists := sr.byName[name]; exists {
+		return fmt.Errorf("%w: %s", ErrTypeRegistered, name)
+	}
+	if _, exists := sr.byType[typ]; exists {
+		return fmt.Errorf("%w: %v", ErrTypeRegistered, typ)
+	}
+	entry := &messageType{name: name, typ: typ, serializer: serializer}
+	sr.byName[name] = entry
+	sr.byType[typ] = entry
+	return nil
+}
+
+// Returns name under which type of message is sent to other nodes
+func (sr *SerializerRegistry) TypeName(message interface{}) (string, error) {
+	sr.mu.RLock()
+	entry, exists := sr.byType[reflect.TypeOf(message)]
+	sr.mu.RUnlock()
+	if exists {
+		return entry.name, nil
+	}
+	if protoMessage, ok := message.(proto.Message); ok {
+		return string(proto.MessageName(protoMessage)), nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnknownMessageType, message)
+}
+
+// Returns type name and serialized message
+func (sr *SerializerRegistry) Serialize(message interface{}) (string, []byte, error) {
+	sr.mu.RLock()
+	entry, exists := sr.byType[reflect.TypeOf(message)]
+	sr.mu.RUnlock()
+	if exists {
+		data, err := entry.serializer.Serialize(message)
+		return entry.name, data, err
+	}
+
+	if protoMessage, ok := message.(proto.Message); ok {
+		data, err := proto.Marshal(protoMessage)
+		return string(proto.MessageName(protoMessage)), data, err
+	}
+	return "", nil, fmt.Errorf("%w: %T", ErrUnknownMessageType, message)
+}
+
+// Creates message of type registered under name from data
+func (sr *SerializerRegistry) Deserialize(name string, data []byte) (interface{}, error) {
+	sr.mu.RLock()
+	entry, exists := sr.byName[name]
+	sr.mu.RUnlock()
+	if exists {
+		return entry.deserialize(data)
+	}
+
+	protoType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessageType, name)
+	}
+	message := protoType.New().Interface()
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (mt *messageType) deserialize(data []byte) (interface{}, error) {
+	if mt.typ.Kind() == reflect.Ptr {
+		message := reflect.New(mt.typ.Elem())
+		if err := mt.serializer.Deserialize(data, message.Interface()); err != nil {
+			return nil, err
+		}
+		return message.Interface(), nil
+	}
+	message := reflect.New(mt.typ)
+	if err := mt.serializer.Deserialize(data, message.Interface()); err != nil {
+		return nil, err
+	}
+	return message.Elem().Interface(), nil
+}
+
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return ""
+	}
+	if typ.Kind() == reflect.Ptr {
+		return "*" + typeName(typ.Elem())
+	}
+	if typ.PkgPath() == "" {
+		return typ.String()
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
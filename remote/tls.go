@@ -0,0 +1,87 @@
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var ErrNoServerCertificate = errors.New("tls config has no server certificate")
+
+// TLSConfig secures connections between nodes. Node with TLS config can only talk to nodes
+// that use TLS too
+type TLSConfig struct {
+	ServerCertificate *tls.Certificate // Presented by listener to connecting nodes
+	ClientCertificate *tls.Certificate // Presented by endpoints to other nodes, required by nodes with MutualTLS
+	CAs               *x509.CertPool   // Verifies certificates of other nodes, system pool is used when nil
+	MutualTLS         bool             // Listener accepts only nodes with client certificate signed by CAs
+	ServerName        string           // Name expected in certificates of other nodes, host of address is used when empty
+}
+
+// Loads PEM encoded CA certificates from file
+func LoadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return pool, nil
+}
+
+func (c *TLSConfig) serverConfig() (*tls.Config, error) {
+	if c.ServerCertificate == nil {
+		return nil, ErrNoServerCertificate
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{*c.ServerCertificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.MutualTLS {
+		if c.CAs == nil {
+			return nil, errors.New("mutual tls requires CAs to verify client certificates")
+		}
+		config.ClientCAs = c.CAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+func (c *TLSConfig) clientConfig() *tls.Config {
+	config := &tls.Config{
+		RootCAs:    c.CAs,
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.ClientCertificate != nil {
+		config.Certificates = []tls.Certificate{*c.ClientCertificate}
+	}
+	return config
+}
+
+// Returns credentials of listener, connections are not encrypted when config has no TLS
+func (rc *RemoteConfig) serverCredentials() (credentials.TransportCredentials, error) {
+	if rc.TLS == nil {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := rc.TLS.serverConfig()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// Returns credentials of connections to other nodes
+func (rc *RemoteConfig) clientCredentials() credentials.TransportCredentials {
+	if rc.TLS == nil {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(rc.TLS.clientConfig())
+}
@@ -0,0 +1,228 @@
+package remote_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"light-actor-go/actor"
+	"light-actor-go/remote"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+type certAuthority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newCertAuthority(t *testing.T) *certAuthority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create ca certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse ca certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &certAuthority{cert: cert, key: key, pool: pool}
+}
+
+// Issues certificate for 127.0.0.1 that can be used by both listener and endpoints
+func (ca *certAuthority) issue(t *testing.T, name string) *tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to get free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+type Ping struct {
+	Value string
+}
+
+type receiverActor struct {
+	received chan string
+}
+
+func (a *receiverActor) Receive(ctx actor.ActorContext) {
+	if msg, ok := ctx.Message().(Ping); ok {
+		a.received <- msg.Value
+	}
+}
+
+// Starts node with discoverable actor named receiver
+func startReceiverNode(t *testing.T, tlsConfig *remote.TLSConfig) (string, chan string) {
+	t.Helper()
+	config := remote.NewRemoteConfig(freeAddress(t))
+	config.TLS = tlsConfig
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start receiver node: %v", err)
+	}
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+
+	received := make(chan string, 1)
+	pid, err := system.SpawnActor(&receiverActor{received: received})
+	if err != nil {
+		t.Fatalf("failed to spawn receiver: %v", err)
+	}
+	r.MakeActorDiscoverable(pid, "receiver")
+	return config.Addr, received
+}
+
+func startSenderNode(t *testing.T, tlsConfig *remote.TLSConfig) (*remote.Remote, *actor.ActorSystem) {
+	t.Helper()
+	config := remote.NewRemoteConfig(freeAddress(t))
+	config.TLS = tlsConfig
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(*config, system)
+	r.Serializers().Register(Ping{}, remote.JSONSerializer)
+	t.Cleanup(func() { r.Shutdown(context.Background()) })
+	return r, system
+}
+
+func sendPing(t *testing.T, address string, sender *remote.Remote, system *actor.ActorSystem, received chan string) {
+	t.Helper()
+	pid, err := sender.SpawnRemoteActor(address, "receiver")
+	if err != nil {
+		t.Fatalf("failed to resolve receiver: %v", err)
+	}
+	system.Send(actor.NewEnvelope(Ping{Value: "ping"}, pid))
+	select {
+	case value := <-received:
+		if value != "ping" {
+			t.Fatalf("received %q, expected ping", value)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+	}
+}
+
+func TestTLS(t *testing.T) {
+	ca := newCertAuthority(t)
+	address, received := startReceiverNode(t, &remote.TLSConfig{
+		ServerCertificate: ca.issue(t, "receiver"),
+		CAs:               ca.pool,
+	})
+	sender, system := startSenderNode(t, &remote.TLSConfig{CAs: ca.pool})
+
+	sendPing(t, address, sender, system, received)
+}
+
+func TestTLSUnknownAuthority(t *testing.T) {
+	ca := newCertAuthority(t)
+	address, _ := startReceiverNode(t, &remote.TLSConfig{ServerCertificate: ca.issue(t, "receiver")})
+	sender, _ := startSenderNode(t, &remote.TLSConfig{CAs: newCertAuthority(t).pool})
+
+	if _, err := sender.SpawnRemoteActor(address, "receiver"); err == nil {
+		t.Fatal("expected error when server certificate is signed by unknown authority")
+	}
+}
+
+func TestTLSInsecureNode(t *testing.T) {
+	ca := newCertAuthority(t)
+	address, _ := startReceiverNode(t, &remote.TLSConfig{ServerCertificate: ca.issue(t, "receiver")})
+	sender, _ := startSenderNode(t, nil)
+
+	if _, err := sender.SpawnRemoteActor(address, "receiver"); err == nil {
+		t.Fatal("expected error when node without tls connects to tls node")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newCertAuthority(t)
+	address, received := startReceiverNode(t, &remote.TLSConfig{
+		ServerCertificate: ca.issue(t, "receiver"),
+		CAs:               ca.pool,
+		MutualTLS:         true,
+	})
+	sender, system := startSenderNode(t, &remote.TLSConfig{
+		ClientCertificate: ca.issue(t, "sender"),
+		CAs:               ca.pool,
+	})
+
+	sendPing(t, address, sender, system, received)
+}
+
+func TestMutualTLSRejectsClient(t *testing.T) {
+	ca := newCertAuthority(t)
+	address, _ := startReceiverNode(t, &remote.TLSConfig{
+		ServerCertificate: ca.issue(t, "receiver"),
+		CAs:               ca.pool,
+		MutualTLS:         true,
+	})
+
+	tests := map[string]*remote.TLSConfig{
+		"no client certificate": {CAs: ca.pool},
+		"unknown authority":     {ClientCertificate: newCertAuthority(t).issue(t, "sender"), CAs: ca.pool},
+	}
+	for name, tlsConfig := range tests {
+		t.Run(name, func(t *testing.T) {
+			sender, _ := startSenderNode(t, tlsConfig)
+			if _, err := sender.SpawnRemoteActor(address, "receiver"); err == nil {
+				t.Fatal("expected error when client certificate is not accepted")
+			}
+		})
+	}
+}
+
+func TestTLSWithoutServerCertificate(t *testing.T) {
+	config := remote.NewRemoteConfig(freeAddress(t))
+	config.TLS = &remote.TLSConfig{CAs: newCertAuthority(t).pool}
+	r := remote.NewRemote(*config, actor.NewActorSystem())
+	defer r.Shutdown(context.Background())
+
+	if err := r.Start(); !errors.Is(err, remote.ErrNoServerCertificate) {
+		t.Fatalf("expected ErrNoServerCertificate, got %v", err)
+	}
+}